@@ -1,10 +1,12 @@
 package main
 
 import (
+	"context"
 	"log"
 
 	"github.com/hiveden/hiveden/internal/api"
 	"github.com/hiveden/hiveden/internal/docker"
+	"github.com/hiveden/hiveden/internal/errdefs"
 
 	"github.com/gin-gonic/gin"
 	"github.com/spf13/viper"
@@ -12,12 +14,30 @@ import (
 
 func main() {
 	viper.SetDefault("network_name", docker.DefaultNetworkName)
-	v,_ := docker.NewDockerManager(viper.GetString("network_name"))
-	dm := v
+	viper.SetEnvPrefix("hiveden")
+	viper.BindEnv("connection.host", "HIVEDEN_HOST")
+	viper.AutomaticEnv()
+
+	conn := docker.ConnectionConfig{
+		Host:                  viper.GetString("connection.host"),
+		TLSCACert:             viper.GetString("connection.tls_ca_cert"),
+		TLSCert:               viper.GetString("connection.tls_cert"),
+		TLSKey:                viper.GetString("connection.tls_key"),
+		TLSInsecureSkipVerify: viper.GetBool("connection.tls_insecure_skip_verify"),
+		SSHIdentity:           viper.GetString("connection.ssh_identity"),
+	}
+
+	dm, err := docker.NewDockerManager(viper.GetString("network_name"), conn)
+	if err != nil {
+		log.Fatalf("failed to create Docker manager: %v", err)
+	}
 
 	apiHandler := api.NewAPIHandler(dm)
 
+	go dm.Supervise(context.Background())
+
 	r := gin.Default()
+	r.Use(errdefs.Middleware())
 
 	dockerGroup := r.Group("/docker")
 
@@ -25,9 +45,16 @@ func main() {
 	{
 		containersGroup.GET("", apiHandler.ListContainers)
 		containersGroup.POST("", apiHandler.CreateContainer)
+		containersGroup.DELETE("", apiHandler.PruneContainers)
+		containersGroup.POST("/import", apiHandler.ImportContainers)
 		containersGroup.POST("/:id/start", apiHandler.StartContainer)
 		containersGroup.POST("/:id/stop", apiHandler.StopContainer)
 		containersGroup.DELETE("/:id", apiHandler.RemoveContainer)
+		containersGroup.GET("/:id/logs", apiHandler.GetContainerLogs)
+		containersGroup.GET("/:id/stats", apiHandler.GetContainerStats)
+		containersGroup.POST("/:id/exec", apiHandler.ExecContainer)
+		containersGroup.POST("/:id/healthcheck/run", apiHandler.RunContainerHealthcheck)
+		containersGroup.GET("/:id/health", apiHandler.GetContainerHealth)
 	}
 
 	networksGroup := dockerGroup.Group("/networks")
@@ -37,6 +64,20 @@ func main() {
 		networksGroup.DELETE("/:id", apiHandler.RemoveNetwork)
 	}
 
+	volumesGroup := dockerGroup.Group("/volumes")
+	{
+		volumesGroup.GET("", apiHandler.ListVolumes)
+		volumesGroup.POST("", apiHandler.CreateVolume)
+		volumesGroup.DELETE("/:name", apiHandler.RemoveVolume)
+	}
+
+	r.GET("/events", apiHandler.StreamEvents)
+
+	systemGroup := r.Group("/system")
+	{
+		systemGroup.GET("/platform", apiHandler.GetPlatform)
+	}
+
 	if err := r.Run(":8080"); err != nil {
 		log.Fatalf("failed to run server: %v", err)
 	}
@@ -1,12 +1,13 @@
 package main
 
 import (
+	"encoding/json"
 	"fmt"
-	"log"
 	"os"
+	"strings"
+	"time"
 
 	"github.com/hiveden/hiveden/internal/docker"
-	"gopkg.in/yaml.v2"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -18,8 +19,11 @@ func main() {
 	rootCmd := &cobra.Command{
 		Use: "hiveden",
 		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
-			var err error
-			dockerManager, err = docker.NewDockerManager(viper.GetString("network_name"))
+			conn, err := connectionFromViper()
+			if err != nil {
+				return err
+			}
+			dockerManager, err = docker.NewDockerManager(viper.GetString("network_name"), conn)
 			if err != nil {
 				return fmt.Errorf("failed to create Docker manager: %v", err)
 			}
@@ -31,6 +35,13 @@ func main() {
 	viper.BindPFlag("network_name", rootCmd.PersistentFlags().Lookup("network-name"))
 	viper.SetDefault("network_name", docker.DefaultNetworkName)
 
+	rootCmd.PersistentFlags().String("connection", "", "Named connection (see 'hiveden system connection list') or a raw tcp://, ssh:// host URI")
+	viper.BindPFlag("connection", rootCmd.PersistentFlags().Lookup("connection"))
+
+	viper.SetEnvPrefix("hiveden")
+	viper.BindEnv("connection.host", "HIVEDEN_HOST")
+	viper.AutomaticEnv()
+
 	var configFile string
 	rootCmd.PersistentFlags().StringVar(&configFile, "config", "cmd/cli/config.yaml", "config file (default is cmd/cli/config.yaml)")
 
@@ -44,12 +55,19 @@ func main() {
 	containersCmd.AddCommand(buildStartCommand())
 	containersCmd.AddCommand(buildStopCommand())
 	containersCmd.AddCommand(buildRemoveCommand())
-	containersCmd.AddCommand(buildRunAllCommand(&configFile))
+	containersCmd.AddCommand(buildPruneCommand())
+	containersCmd.AddCommand(buildStatsCommand())
 
 	rootCmd.AddCommand(containersCmd)
 
 	containersCmd.AddCommand(buildExportCommand())
 
+	rootCmd.AddCommand(buildPlayCommand(&configFile))
+
+	rootCmd.AddCommand(buildSystemCommand())
+
+	rootCmd.AddCommand(buildSuperviseCommand())
+
 	if err := rootCmd.Execute(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
@@ -82,13 +100,13 @@ func buildListCommand() *cobra.Command {
 }
 
 func buildCreateCommand() *cobra.Command {
-	var imageName, containerName string
+	var imageName, containerName, platform string
 
 	cmd := &cobra.Command{
 		Use:   "create",
 		Short: "Create a new container",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			resp, err := dockerManager.CreateContainer(cmd.Context(), imageName, containerName)
+			resp, err := dockerManager.CreateContainer(cmd.Context(), imageName, containerName, platform, nil)
 			if err != nil {
 				return err
 			}
@@ -100,6 +118,7 @@ func buildCreateCommand() *cobra.Command {
 
 	cmd.Flags().StringVar(&imageName, "image", "", "Image name for the container")
 	cmd.Flags().StringVar(&containerName, "name", "", "Name for the container")
+	cmd.Flags().StringVar(&platform, "platform", "", "Platform to create the container for, as os/arch[/variant] (default is the daemon's)")
 	cmd.MarkFlagRequired("image")
 
 	return cmd
@@ -138,51 +157,146 @@ func buildRemoveCommand() *cobra.Command {
 	}
 }
 
-type ContainerConfig struct {
-	Name  string `yaml:"name"`
-	Image string `yaml:"image"`
+// buildPruneCommand builds `hiveden containers prune`, which removes every
+// hiveden-managed container regardless of manifest, so cleanup is reliable
+// even across restarts when no manifest is on hand.
+func buildPruneCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "prune",
+		Short: "Remove all hiveden-managed containers",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			removed, err := dockerManager.PruneManagedContainers(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			for _, id := range removed {
+				fmt.Printf("Removed container %s\n", id)
+			}
+			return nil
+		},
+	}
 }
 
-type Config struct {
-	Containers []ContainerConfig `yaml:"containers"`
+// buildStatsCommand builds `hiveden containers stats`, which polls a
+// snapshot per container and renders it as a refreshing table (or, with
+// --no-stream, prints a single snapshot and exits).
+func buildStatsCommand() *cobra.Command {
+	var noStream bool
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "stats [id...]",
+		Short: "Show live resource usage for one or more containers",
+		Args:  cobra.MinimumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			for {
+				snapshot := make(map[string]docker.ContainerStats, len(args))
+				for _, id := range args {
+					ch, err := dockerManager.Stats(cmd.Context(), id, false)
+					if err != nil {
+						return err
+					}
+					stats, ok := <-ch
+					if !ok {
+						return fmt.Errorf("no stats returned for %s", id)
+					}
+					snapshot[id] = stats
+				}
+
+				if err := printStats(args, snapshot, format, !noStream); err != nil {
+					return err
+				}
+
+				if noStream {
+					return nil
+				}
+				time.Sleep(time.Second)
+			}
+		},
+	}
+
+	cmd.Flags().BoolVar(&noStream, "no-stream", false, "Print a single snapshot and exit")
+	cmd.Flags().StringVar(&format, "format", "table", `Output format: "table" or "json"`)
+
+	return cmd
 }
 
-func buildRunAllCommand(configFile *string) *cobra.Command {
-	return &cobra.Command{
-		Use:   "run-all",
-		Short: "Create and start all containers from a config file",
+func printStats(ids []string, snapshot map[string]docker.ContainerStats, format string, clearScreen bool) error {
+	if format == "json" {
+		data, err := json.Marshal(snapshot)
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+		return nil
+	}
+
+	if clearScreen {
+		fmt.Print("\033[H\033[2J")
+	}
+	fmt.Printf("%-20s %8s %12s %12s %16s %16s\n", "CONTAINER", "CPU %", "MEM USAGE", "MEM LIMIT", "NET RX/TX", "BLOCK R/W")
+	for _, id := range ids {
+		s := snapshot[id]
+		fmt.Printf("%-20s %7.2f%% %12d %12d %7d/%-8d %7d/%-8d\n",
+			id, s.CPUPercent, s.MemoryUsage, s.MemoryLimit, s.NetworkRxBytes, s.NetworkTxBytes, s.BlockReadBytes, s.BlockWriteBytes)
+	}
+	return nil
+}
+
+// buildPlayCommand builds `hiveden play`, a Kubernetes-/podman-play-kube-style
+// declarative apply: it reconciles the host to the containers, networks, and
+// volumes described in a manifest file, replacing the old best-effort
+// run-all loop with an idempotent engine that understands depends_on order.
+func buildPlayCommand(configFile *string) *cobra.Command {
+	var dryRun, prune bool
+	var manifestFile string
+
+	cmd := &cobra.Command{
+		Use:   "play",
+		Short: "Reconcile the host to a declarative YAML manifest",
 		RunE: func(cmd *cobra.Command, args []string) error {
-			data, err := os.ReadFile(*configFile)
+			path := *configFile
+			if manifestFile != "" {
+				path = manifestFile
+			}
+			data, err := os.ReadFile(path)
 			if err != nil {
-				return fmt.Errorf("failed to read config file: %w", err)
+				return fmt.Errorf("failed to read manifest file: %w", err)
 			}
 
-			var config Config
-			if err := yaml.Unmarshal(data, &config); err != nil {
-				return fmt.Errorf("failed to unmarshal config: %w", err)
+			manifest, err := docker.ParseManifest(data)
+			if err != nil {
+				return err
 			}
 
-			for _, containerConfig := range config.Containers {
-				fmt.Printf("Creating container %s with image %s...\n", containerConfig.Name, containerConfig.Image)
-				resp, err := dockerManager.CreateContainer(cmd.Context(), containerConfig.Image, containerConfig.Name)
+			opts := docker.PlayOptions{Prune: prune}
+
+			if dryRun {
+				actions, err := dockerManager.Plan(cmd.Context(), manifest, opts)
 				if err != nil {
-					log.Printf("Failed to create container %s: %v", containerConfig.Name, err)
-					continue
+					return err
 				}
-
-				fmt.Printf("Starting container %s (%s)...\n", containerConfig.Name, resp.ID[:12])
-				if err := dockerManager.StartContainer(cmd.Context(), resp.ID); err != nil {
-					log.Printf("Failed to start container %s: %v", containerConfig.Name, err)
+				for _, a := range actions {
+					fmt.Printf("%s %s: %s\n", a.Action, a.Kind, a.Name)
 				}
+				return nil
 			}
 
-			return nil
+			return dockerManager.Apply(cmd.Context(), manifest, opts)
 		},
 	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Print the diff between current and desired state without applying it")
+	cmd.Flags().BoolVar(&prune, "prune", false, "Remove hiveden-managed resources that are no longer in the manifest")
+	cmd.Flags().StringVarP(&manifestFile, "file", "f", "", "Manifest file to apply (default is the --config file)")
+
+	return cmd
 }
 
 func buildExportCommand() *cobra.Command {
-	var filePath string
+	var filePath, format string
+	var includeStopped bool
 
 	cmd := &cobra.Command{
 		Use:   "export",
@@ -191,11 +305,197 @@ func buildExportCommand() *cobra.Command {
 			if filePath == "" {
 				return fmt.Errorf("file path must be specified with --file")
 			}
-			return dockerManager.ExportManagedContainers(cmd.Context(), filePath)
+			switch format {
+			case "yaml", "json", "compose":
+			default:
+				return fmt.Errorf("--format must be one of yaml, json, compose, got %q", format)
+			}
+			return dockerManager.ExportManagedContainers(cmd.Context(), filePath, docker.ExportOptions{
+				IncludeStopped: includeStopped,
+				Format:         format,
+			})
 		},
 	}
 
 	cmd.Flags().StringVar(&filePath, "file", "", "File path to export the configuration to")
+	cmd.Flags().StringVar(&format, "format", "yaml", "Export format: yaml, json, or compose")
+	cmd.Flags().BoolVar(&includeStopped, "include-stopped", false, "Include stopped containers in the export")
 
 	return cmd
 }
+
+// buildSuperviseCommand builds `hiveden supervise`, a foreground process
+// that restarts hiveden-managed containers on die/unhealthy events with
+// exponential backoff. Run it alongside the API server, or standalone for
+// CLI-only deployments.
+func buildSuperviseCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "supervise",
+		Short: "Watch hiveden-managed containers and restart failed or unhealthy ones",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return dockerManager.Supervise(cmd.Context())
+		},
+	}
+}
+
+// connectionsFilePath returns where named connections are persisted,
+// falling back to "" (treated as "no connections configured") if the user's
+// config directory can't be determined.
+func connectionsFilePath() string {
+	path, err := docker.DefaultConnectionsFilePath()
+	if err != nil {
+		return ""
+	}
+	return path
+}
+
+// connectionFromViper resolves the --connection flag (or HIVEDEN_HOST) into
+// a ConnectionConfig: a raw "scheme://" value is used as-is, anything else
+// is looked up by name in the connections file, and an empty selection
+// falls back to that file's default connection (or the local daemon). A
+// named connection that isn't found is an error, not a fallback — running
+// against the wrong daemon because of a typo'd name must never happen
+// silently.
+func connectionFromViper() (docker.ConnectionConfig, error) {
+	store, err := docker.LoadConnectionStore(connectionsFilePath())
+	if err != nil {
+		store = &docker.ConnectionStore{}
+	}
+
+	switch sel := viper.GetString("connection"); {
+	case strings.Contains(sel, "://"):
+		return docker.ConnectionConfig{Host: sel}, nil
+	case sel != "":
+		conn, err := store.Get(sel)
+		if err != nil {
+			return docker.ConnectionConfig{}, fmt.Errorf("connection %q: %w", sel, err)
+		}
+		return conn, nil
+	}
+
+	if host := viper.GetString("connection.host"); host != "" {
+		return docker.ConnectionConfig{Host: host}, nil
+	}
+
+	conn, _ := store.Get("")
+	return conn, nil
+}
+
+// buildSystemCommand builds `hiveden system`, home for host-level commands
+// that don't act on a specific container — currently just named connection
+// management. Its subtree overrides the root's PersistentPreRunE so editing
+// connections doesn't require a reachable Docker daemon.
+func buildSystemCommand() *cobra.Command {
+	systemCmd := &cobra.Command{
+		Use:               "system",
+		Short:             "Host- and daemon-level commands",
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error { return nil },
+	}
+
+	connectionCmd := &cobra.Command{
+		Use:   "connection",
+		Short: "Manage named Docker connections",
+	}
+
+	connectionCmd.AddCommand(buildConnectionAddCommand())
+	connectionCmd.AddCommand(buildConnectionRemoveCommand())
+	connectionCmd.AddCommand(buildConnectionListCommand())
+	connectionCmd.AddCommand(buildConnectionDefaultCommand())
+
+	systemCmd.AddCommand(connectionCmd)
+
+	return systemCmd
+}
+
+func buildConnectionAddCommand() *cobra.Command {
+	var conn docker.ConnectionConfig
+
+	cmd := &cobra.Command{
+		Use:   "add [name]",
+		Short: "Add or update a named connection",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := docker.LoadConnectionStore(connectionsFilePath())
+			if err != nil {
+				return err
+			}
+
+			conn.Name = args[0]
+			store.Upsert(conn)
+
+			return store.Save(connectionsFilePath())
+		},
+	}
+
+	cmd.Flags().StringVar(&conn.Host, "host", "", `Connection URI, e.g. "tcp://host:2376" or "ssh://user@host"`)
+	cmd.Flags().StringVar(&conn.TLSCACert, "tls-ca-cert", "", "Path to the TLS CA certificate")
+	cmd.Flags().StringVar(&conn.TLSCert, "tls-cert", "", "Path to the TLS client certificate")
+	cmd.Flags().StringVar(&conn.TLSKey, "tls-key", "", "Path to the TLS client key")
+	cmd.Flags().BoolVar(&conn.TLSInsecureSkipVerify, "tls-insecure-skip-verify", false, "Skip verifying the daemon's TLS certificate (unsafe; only for self-signed daemons)")
+	cmd.Flags().StringVar(&conn.SSHIdentity, "ssh-identity", "", "Path to the SSH identity file for ssh:// connections")
+	cmd.MarkFlagRequired("host")
+
+	return cmd
+}
+
+func buildConnectionRemoveCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove [name]",
+		Short: "Remove a named connection",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := docker.LoadConnectionStore(connectionsFilePath())
+			if err != nil {
+				return err
+			}
+
+			store.Remove(args[0])
+
+			return store.Save(connectionsFilePath())
+		},
+	}
+}
+
+func buildConnectionListCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "list",
+		Short: "List named connections",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := docker.LoadConnectionStore(connectionsFilePath())
+			if err != nil {
+				return err
+			}
+
+			for _, c := range store.Connections {
+				marker := " "
+				if c.Name == store.Default {
+					marker = "*"
+				}
+				fmt.Printf("%s %s\t%s\n", marker, c.Name, c.Host)
+			}
+
+			return nil
+		},
+	}
+}
+
+func buildConnectionDefaultCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "default [name]",
+		Short: "Set the default connection used when --connection isn't given",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			store, err := docker.LoadConnectionStore(connectionsFilePath())
+			if err != nil {
+				return err
+			}
+
+			if _, err := store.Get(args[0]); err != nil {
+				return err
+			}
+			store.Default = args[0]
+
+			return store.Save(connectionsFilePath())
+		},
+	}
+}
@@ -0,0 +1,16 @@
+package api
+
+import (
+	"github.com/hiveden/hiveden/internal/docker"
+)
+
+// APIHandler holds the dependencies shared by every HTTP handler in this
+// package.
+type APIHandler struct {
+	dm *docker.DockerManager
+}
+
+// NewAPIHandler creates a new APIHandler backed by the given DockerManager.
+func NewAPIHandler(dm *docker.DockerManager) *APIHandler {
+	return &APIHandler{dm: dm}
+}
@@ -0,0 +1,234 @@
+package api
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hiveden/hiveden/internal/docker"
+	"github.com/hiveden/hiveden/internal/errdefs"
+)
+
+// ListContainers handles the GET /docker/containers endpoint. Filters mirror
+// Docker's own filter API: repeated ?label=key=value, ?name=, ?status=, and
+// ?ancestor= query parameters narrow the result; with none set, the result
+// is narrowed only by ?all= (running containers unless all=true).
+func (h *APIHandler) ListContainers(c *gin.Context) {
+	all := c.Query("all") == "true"
+	filter := containerFilterFromQuery(c)
+
+	if isZeroContainerFilter(filter) {
+		containers, err := h.dm.ListContainers(c.Request.Context(), all)
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		c.JSON(http.StatusOK, containers)
+		return
+	}
+
+	containers, err := h.dm.ListContainersFiltered(c.Request.Context(), all, filter)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, containers)
+}
+
+func containerFilterFromQuery(c *gin.Context) docker.ContainerFilter {
+	filter := docker.ContainerFilter{
+		Name:     c.Query("name"),
+		Status:   c.Query("status"),
+		Ancestor: c.Query("ancestor"),
+	}
+
+	for _, label := range c.QueryArray("label") {
+		k, v, ok := strings.Cut(label, "=")
+		if !ok {
+			continue
+		}
+		if filter.Labels == nil {
+			filter.Labels = map[string]string{}
+		}
+		filter.Labels[k] = v
+	}
+
+	return filter
+}
+
+func isZeroContainerFilter(f docker.ContainerFilter) bool {
+	return len(f.Labels) == 0 && f.Name == "" && f.Status == "" && f.Ancestor == ""
+}
+
+// PruneContainers handles the DELETE /docker/containers endpoint with
+// ?prune=managed, the only mode currently supported: it removes every
+// container hiveden created, so cleanup is reliable even across restarts.
+func (h *APIHandler) PruneContainers(c *gin.Context) {
+	if c.Query("prune") != "managed" {
+		c.Error(errdefs.InvalidParameter(fmt.Errorf("prune must be %q", "managed")))
+		return
+	}
+
+	removed, err := h.dm.PruneManagedContainers(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"removed": removed})
+}
+
+// createContainerRequest is the JSON body for POST /docker/containers.
+type createContainerRequest struct {
+	Image    string         `json:"image"`
+	Name     string         `json:"name"`
+	Platform string         `json:"platform"`
+	Mounts   []mountRequest `json:"mounts"`
+}
+
+type mountRequest struct {
+	VolumeName string `json:"volumeName"`
+	Target     string `json:"target"`
+	ReadOnly   bool   `json:"readOnly"`
+}
+
+// CreateContainer handles the POST /docker/containers endpoint.
+func (h *APIHandler) CreateContainer(c *gin.Context) {
+	var reqBody createContainerRequest
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(errdefs.InvalidParameter(err))
+		return
+	}
+
+	mounts := make([]docker.Mount, 0, len(reqBody.Mounts))
+	for _, m := range reqBody.Mounts {
+		mounts = append(mounts, docker.Mount{VolumeName: m.VolumeName, Target: m.Target, ReadOnly: m.ReadOnly})
+	}
+
+	resp, err := h.dm.CreateContainer(c.Request.Context(), reqBody.Image, reqBody.Name, reqBody.Platform, mounts)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
+// StartContainer handles the POST /docker/containers/:id/start endpoint.
+func (h *APIHandler) StartContainer(c *gin.Context) {
+	if err := h.dm.StartContainer(c.Request.Context(), c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// StopContainer handles the POST /docker/containers/:id/stop endpoint.
+func (h *APIHandler) StopContainer(c *gin.Context) {
+	if err := h.dm.StopContainer(c.Request.Context(), c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// RemoveContainer handles the DELETE /docker/containers/:id endpoint.
+func (h *APIHandler) RemoveContainer(c *gin.Context) {
+	if err := h.dm.RemoveContainer(c.Request.Context(), c.Param("id")); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// GetContainerLogs handles the GET /docker/containers/:id/logs endpoint,
+// relaying the container's log stream to the client as it's read. With
+// follow=true the connection is kept open and new lines are flushed as they
+// arrive; otherwise the available backlog is sent and the stream closes.
+func (h *APIHandler) GetContainerLogs(c *gin.Context) {
+	opts := docker.LogOptions{
+		Follow:     c.Query("follow") == "true",
+		Tail:       c.DefaultQuery("tail", "all"),
+		Since:      c.Query("since"),
+		Until:      c.Query("until"),
+		Timestamps: c.Query("timestamps") == "true",
+	}
+
+	rc, err := h.dm.ContainerLogs(c.Request.Context(), c.Param("id"), opts)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer rc.Close()
+
+	buf := make([]byte, 4096)
+	c.Stream(func(w io.Writer) bool {
+		n, err := rc.Read(buf)
+		if n > 0 {
+			w.Write(buf[:n])
+		}
+		return err == nil
+	})
+}
+
+// GetContainerStats handles the GET /docker/containers/:id/stats endpoint,
+// relaying normalized ContainerStats snapshots as Server-Sent Events. With
+// stream=false (the default) only a single snapshot is sent.
+func (h *APIHandler) GetContainerStats(c *gin.Context) {
+	stream := c.Query("stream") == "true"
+
+	statsCh, err := h.dm.Stats(c.Request.Context(), c.Param("id"), stream)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Stream(func(w io.Writer) bool {
+		stats, ok := <-statsCh
+		if !ok {
+			return false
+		}
+		c.SSEvent("stats", stats)
+		return true
+	})
+}
+
+// RunContainerHealthcheck handles the POST
+// /docker/containers/:id/healthcheck/run endpoint, forcing an immediate
+// healthcheck probe instead of waiting for the daemon's own interval.
+func (h *APIHandler) RunContainerHealthcheck(c *gin.Context) {
+	result, err := h.dm.RunHealthcheck(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, result)
+}
+
+// GetContainerHealth handles the GET /docker/containers/:id/health endpoint,
+// returning up to ?n= (default 5) of the container's most recent healthcheck
+// results, newest first.
+func (h *APIHandler) GetContainerHealth(c *gin.Context) {
+	n, err := strconv.Atoi(c.DefaultQuery("n", "5"))
+	if err != nil || n <= 0 {
+		c.Error(errdefs.InvalidParameter(fmt.Errorf("n must be a positive integer")))
+		return
+	}
+
+	results, err := h.dm.GetHealth(c.Request.Context(), c.Param("id"), n)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
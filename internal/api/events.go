@@ -0,0 +1,39 @@
+package api
+
+import (
+	"io"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hiveden/hiveden/internal/docker"
+)
+
+// StreamEvents handles the GET /events endpoint, relaying hiveden-managed
+// lifecycle events to the client as Server-Sent Events until the client
+// disconnects.
+func (h *APIHandler) StreamEvents(c *gin.Context) {
+	events, err := h.dm.Watch(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Stream(func(w io.Writer) bool {
+		event, ok := <-events
+		if !ok {
+			return false
+		}
+		c.SSEvent(event.Kind, toEventPayload(event))
+		return true
+	})
+}
+
+func toEventPayload(e docker.Event) gin.H {
+	return gin.H{
+		"action":     e.Action,
+		"id":         e.ID,
+		"name":       e.Name,
+		"time":       e.Time,
+		"attributes": e.Attributes,
+	}
+}
@@ -0,0 +1,146 @@
+package api
+
+import (
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/docker/docker/pkg/stdcopy"
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"github.com/hiveden/hiveden/internal/docker"
+	"github.com/hiveden/hiveden/internal/errdefs"
+)
+
+var execUpgrader = websocket.Upgrader{
+	// The API is consumed by hiveden's own UI/CLI over whatever origin they're
+	// served from; there's no cookie-based auth to protect against CSRF here.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+// execResizeMessage is the control frame a client sends (as a text message)
+// to resize the TTY of an in-progress exec.
+type execResizeMessage struct {
+	Type   string `json:"type"`
+	Height uint   `json:"height"`
+	Width  uint   `json:"width"`
+}
+
+// ExecContainer handles the POST /docker/containers/:id/exec endpoint,
+// creating an exec instance for the given command and upgrading the
+// connection to a WebSocket that carries its stdout/stderr framed as
+// [stream(1), 0, 0, 0, size(4)] followed by size bytes of payload, and
+// accepts raw binary frames as stdin plus JSON resize control frames.
+func (h *APIHandler) ExecContainer(c *gin.Context) {
+	cmd := c.QueryArray("cmd")
+	if len(cmd) == 0 {
+		c.Error(errdefs.InvalidParameter(fmt.Errorf("cmd is required")))
+		return
+	}
+	tty := c.Query("tty") == "true"
+
+	execID, err := h.dm.ExecCreate(c.Request.Context(), c.Param("id"), docker.ExecConfig{
+		Cmd:          cmd,
+		Tty:          tty,
+		AttachStdin:  true,
+		AttachStdout: true,
+		AttachStderr: true,
+	})
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	conn, err := h.dm.ExecStart(c.Request.Context(), execID)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+	defer conn.Close()
+
+	ws, err := execUpgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		return
+	}
+	defer ws.Close()
+
+	go relayExecInput(ws, conn, h.dm, execID)
+	relayExecOutput(ws, conn, tty)
+}
+
+// relayExecInput reads client frames off the WebSocket and forwards them to
+// the exec's stdin, handling resize control frames inline.
+func relayExecInput(ws *websocket.Conn, conn io.Writer, dm *docker.DockerManager, execID string) {
+	for {
+		msgType, data, err := ws.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		if msgType == websocket.TextMessage {
+			var resize execResizeMessage
+			if err := json.Unmarshal(data, &resize); err == nil && resize.Type == "resize" {
+				dm.ExecResize(context.Background(), execID, resize.Height, resize.Width)
+				continue
+			}
+		}
+
+		if _, err := conn.Write(data); err != nil {
+			return
+		}
+	}
+}
+
+// relayExecOutput copies the exec's output to the WebSocket as framed binary
+// messages, demultiplexing stdout/stderr with stdcopy when the exec has no TTY.
+func relayExecOutput(ws *websocket.Conn, conn io.Reader, tty bool) {
+	if tty {
+		buf := make([]byte, 4096)
+		for {
+			n, err := conn.Read(buf)
+			if n > 0 {
+				if writeErr := ws.WriteMessage(websocket.BinaryMessage, frameExecOutput(execStreamStdout, buf[:n])); writeErr != nil {
+					return
+				}
+			}
+			if err != nil {
+				return
+			}
+		}
+	}
+
+	stdcopy.StdCopy(execWriter{ws, execStreamStdout}, execWriter{ws, execStreamStderr}, conn)
+}
+
+const (
+	execStreamStdout byte = 1
+	execStreamStderr byte = 2
+)
+
+// frameExecOutput prepends the [stream(1), 0, 0, 0, size(4)] header the
+// client expects before a chunk of stdout/stderr payload.
+func frameExecOutput(stream byte, payload []byte) []byte {
+	frame := make([]byte, 8+len(payload))
+	frame[0] = stream
+	binary.BigEndian.PutUint32(frame[4:8], uint32(len(payload)))
+	copy(frame[8:], payload)
+	return frame
+}
+
+// execWriter adapts a single stdcopy.StdCopy stream into framed WebSocket
+// binary messages.
+type execWriter struct {
+	ws     *websocket.Conn
+	stream byte
+}
+
+func (w execWriter) Write(p []byte) (int, error) {
+	if err := w.ws.WriteMessage(websocket.BinaryMessage, frameExecOutput(w.stream, p)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
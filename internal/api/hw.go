@@ -12,7 +12,7 @@ import (
 func (h *APIHandler) GetHardwareInfo(c *gin.Context) {
 	hwInfo, err := hw.GetHardwareInfo()
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -0,0 +1,59 @@
+package api
+
+import (
+	"io"
+	"os"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hiveden/hiveden/internal/docker"
+	"github.com/hiveden/hiveden/internal/errdefs"
+)
+
+// ImportContainers handles the POST /docker/containers/import endpoint. It
+// accepts either a `path` query parameter naming a manifest already on disk
+// or a YAML manifest as the request body, and streams the reconcile's pull
+// and lifecycle progress back as Server-Sent Events.
+func (h *APIHandler) ImportContainers(c *gin.Context) {
+	filePath := c.Query("path")
+	if filePath == "" {
+		data, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			c.Error(errdefs.InvalidParameter(err))
+			return
+		}
+
+		tmp, err := os.CreateTemp("", "hiveden-import-*.yaml")
+		if err != nil {
+			c.Error(err)
+			return
+		}
+		defer os.Remove(tmp.Name())
+
+		if _, err := tmp.Write(data); err != nil {
+			tmp.Close()
+			c.Error(err)
+			return
+		}
+		tmp.Close()
+		filePath = tmp.Name()
+	}
+
+	progress := make(chan docker.PullProgress)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- h.dm.ImportManagedContainers(c.Request.Context(), filePath, progress)
+	}()
+
+	c.Stream(func(w io.Writer) bool {
+		p, ok := <-progress
+		if !ok {
+			if err := <-errCh; err != nil {
+				c.SSEvent("error", gin.H{"error": err.Error()})
+			}
+			return false
+		}
+		c.SSEvent("progress", p)
+		return true
+	})
+}
@@ -4,13 +4,15 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+
+	"github.com/hiveden/hiveden/internal/errdefs"
 )
 
 // ListNetworks handles the GET /networks endpoint.
 func (h *APIHandler) ListNetworks(c *gin.Context) {
 	networks, err := h.dm.ListNetworks(c.Request.Context())
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -20,17 +22,18 @@ func (h *APIHandler) ListNetworks(c *gin.Context) {
 // CreateNetwork handles the POST /networks endpoint.
 func (h *APIHandler) CreateNetwork(c *gin.Context) {
 	var reqBody struct {
-		Name string `json:"name"`
+		Name   string            `json:"name"`
+		Labels map[string]string `json:"labels"`
 	}
 
 	if err := c.ShouldBindJSON(&reqBody); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		c.Error(errdefs.InvalidParameter(err))
 		return
 	}
 
-	resp, err := h.dm.CreateNetwork(c.Request.Context(), reqBody.Name)
+	resp, err := h.dm.CreateNetwork(c.Request.Context(), reqBody.Name, reqBody.Labels)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -42,7 +45,7 @@ func (h *APIHandler) RemoveNetwork(c *gin.Context) {
 	networkID := c.Param("id")
 
 	if err := h.dm.RemoveNetwork(c.Request.Context(), networkID); err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.Error(err)
 		return
 	}
 
@@ -0,0 +1,14 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GetPlatform handles the GET /system/platform endpoint, reporting the
+// daemon's effective default platform so clients can pick an image/platform
+// combination the host actually supports.
+func (h *APIHandler) GetPlatform(c *gin.Context) {
+	c.JSON(http.StatusOK, h.dm.Platform())
+}
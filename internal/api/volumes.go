@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/hiveden/hiveden/internal/errdefs"
+)
+
+// ListVolumes handles the GET /docker/volumes endpoint.
+func (h *APIHandler) ListVolumes(c *gin.Context) {
+	volumes, err := h.dm.ListVolumes(c.Request.Context())
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, volumes)
+}
+
+// CreateVolume handles the POST /docker/volumes endpoint.
+func (h *APIHandler) CreateVolume(c *gin.Context) {
+	var reqBody struct {
+		Name       string            `json:"name"`
+		Driver     string            `json:"driver"`
+		DriverOpts map[string]string `json:"driverOpts"`
+		Labels     map[string]string `json:"labels"`
+	}
+
+	if err := c.ShouldBindJSON(&reqBody); err != nil {
+		c.Error(errdefs.InvalidParameter(err))
+		return
+	}
+
+	info, err := h.dm.CreateVolume(c.Request.Context(), reqBody.Name, reqBody.Driver, reqBody.DriverOpts, reqBody.Labels)
+	if err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.JSON(http.StatusOK, info)
+}
+
+// RemoveVolume handles the DELETE /docker/volumes/{name} endpoint.
+func (h *APIHandler) RemoveVolume(c *gin.Context) {
+	force := c.Query("force") == "true"
+
+	if err := h.dm.RemoveVolume(c.Request.Context(), c.Param("name"), force); err != nil {
+		c.Error(err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
@@ -0,0 +1,82 @@
+package docker
+
+// composeFile is a minimal Compose v3 document, just enough to round-trip
+// what GenerateManifest can recover from a running container (in the
+// spirit of `podman generate kube`, but targeting Compose instead of Kube
+// YAML since that's what most hiveden users already have tooling for).
+type composeFile struct {
+	Version  string                    `yaml:"version"`
+	Services map[string]composeService `yaml:"services"`
+	Networks map[string]composeNetwork `yaml:"networks,omitempty"`
+	Volumes  map[string]composeVolume  `yaml:"volumes,omitempty"`
+}
+
+type composeService struct {
+	Image       string            `yaml:"image"`
+	Environment []string          `yaml:"environment,omitempty"`
+	Ports       []string          `yaml:"ports,omitempty"`
+	Volumes     []string          `yaml:"volumes,omitempty"`
+	Command     []string          `yaml:"command,omitempty"`
+	Entrypoint  []string          `yaml:"entrypoint,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Restart     string            `yaml:"restart,omitempty"`
+	DependsOn   []string          `yaml:"depends_on,omitempty"`
+	Networks    []string          `yaml:"networks,omitempty"`
+}
+
+type composeNetwork struct {
+	External bool `yaml:"external,omitempty"`
+}
+
+type composeVolume struct {
+	External bool `yaml:"external,omitempty"`
+}
+
+// toComposeFile translates a Manifest into a Compose v3 document. The
+// translation is lossy in one direction that matters: Compose has no
+// equivalent of max_retries for anything but "on-failure", and hiveden's
+// healthcheck and depends_on ordering semantics are expressed differently
+// (Compose's own "test"/"condition" keys), so healthchecks aren't carried
+// over — round-tripping through compose is meant for interop, not backup.
+func toComposeFile(manifest *Manifest) composeFile {
+	compose := composeFile{
+		Version:  "3.8",
+		Services: map[string]composeService{},
+	}
+
+	for _, n := range manifest.Networks {
+		if compose.Networks == nil {
+			compose.Networks = map[string]composeNetwork{}
+		}
+		compose.Networks[n.Name] = composeNetwork{External: true}
+	}
+
+	for _, v := range manifest.Volumes {
+		if compose.Volumes == nil {
+			compose.Volumes = map[string]composeVolume{}
+		}
+		compose.Volumes[v.Name] = composeVolume{External: true}
+	}
+
+	var networkNames []string
+	for _, n := range manifest.Networks {
+		networkNames = append(networkNames, n.Name)
+	}
+
+	for _, c := range manifest.Containers {
+		compose.Services[c.Name] = composeService{
+			Image:       c.Image,
+			Environment: c.Env,
+			Ports:       c.Ports,
+			Volumes:     c.Volumes,
+			Command:     c.Command,
+			Entrypoint:  c.Entrypoint,
+			Labels:      c.Labels,
+			Restart:     c.Restart,
+			DependsOn:   c.DependsOn,
+			Networks:    networkNames,
+		}
+	}
+
+	return compose
+}
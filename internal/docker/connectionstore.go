@@ -0,0 +1,100 @@
+package docker
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v2"
+)
+
+// ConnectionStore is the on-disk shape of the CLI's named connections file:
+// a set of named remote/local daemon connections plus which one to use when
+// --connection isn't given.
+type ConnectionStore struct {
+	Default     string             `yaml:"default,omitempty"`
+	Connections []ConnectionConfig `yaml:"connections,omitempty"`
+}
+
+// DefaultConnectionsFilePath returns where the CLI stores named connections,
+// creating its parent directory if it doesn't exist yet.
+func DefaultConnectionsFilePath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	dir = filepath.Join(dir, "hiveden")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create config directory %s: %w", dir, err)
+	}
+	return filepath.Join(dir, "connections.yaml"), nil
+}
+
+// LoadConnectionStore reads the named connections file at path, returning an
+// empty store if it doesn't exist yet.
+func LoadConnectionStore(path string) (*ConnectionStore, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &ConnectionStore{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read connections file: %w", err)
+	}
+
+	var store ConnectionStore
+	if err := yaml.Unmarshal(data, &store); err != nil {
+		return nil, fmt.Errorf("failed to parse connections file: %w", err)
+	}
+	return &store, nil
+}
+
+// Save writes the store back to path.
+func (s *ConnectionStore) Save(path string) error {
+	data, err := yaml.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("failed to marshal connections file: %w", err)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// Get returns the named connection, or the default connection if name is
+// empty, or the zero ConnectionConfig (local daemon) if none is configured.
+func (s *ConnectionStore) Get(name string) (ConnectionConfig, error) {
+	if name == "" {
+		name = s.Default
+	}
+	if name == "" {
+		return ConnectionConfig{}, nil
+	}
+
+	for _, c := range s.Connections {
+		if c.Name == name {
+			return c, nil
+		}
+	}
+	return ConnectionConfig{}, fmt.Errorf("no connection named %q", name)
+}
+
+// Upsert adds conn, replacing any existing connection with the same name.
+func (s *ConnectionStore) Upsert(conn ConnectionConfig) {
+	for i, c := range s.Connections {
+		if c.Name == conn.Name {
+			s.Connections[i] = conn
+			return
+		}
+	}
+	s.Connections = append(s.Connections, conn)
+}
+
+// Remove deletes the named connection, clearing Default if it pointed at it.
+func (s *ConnectionStore) Remove(name string) {
+	for i, c := range s.Connections {
+		if c.Name == name {
+			s.Connections = append(s.Connections[:i], s.Connections[i+1:]...)
+			break
+		}
+	}
+	if s.Default == name {
+		s.Default = ""
+	}
+}
@@ -3,14 +3,18 @@ package docker
 import (
 	"context"
 	"fmt"
-	"os"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/mount"
 	"github.com/docker/docker/api/types/network"
 	"github.com/docker/docker/client"
-	"gopkg.in/yaml.v2"
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+
+	"github.com/hiveden/hiveden/internal/errdefs"
 )
 
 const (
@@ -19,13 +23,26 @@ const (
 
 // DockerManager provides methods to interact with the Docker API.
 type DockerManager struct {
-	cli         *client.Client
-	networkName string
+	cli             Client
+	networkName     string
+	defaultPlatform v1.Platform
+
+	watchState
+
+	healthMu  sync.Mutex
+	healthLog map[string][]HealthcheckResult
 }
 
-// NewDockerManager creates a new DockerManager instance.
-func NewDockerManager(networkName string) (*DockerManager, error) {
-	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+// NewDockerManager creates a new DockerManager instance, connecting to the
+// daemon described by conn (the zero value connects locally via DOCKER_HOST
+// or the platform default socket).
+func NewDockerManager(networkName string, conn ConnectionConfig) (*DockerManager, error) {
+	opts, err := clientOpts(conn)
+	if err != nil {
+		return nil, err
+	}
+
+	cli, err := client.NewClientWithOpts(opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -34,21 +51,65 @@ func NewDockerManager(networkName string) (*DockerManager, error) {
 		networkName = DefaultNetworkName
 	}
 
-	return &DockerManager{cli: cli, networkName: networkName}, nil
+	dm := &DockerManager{cli: cli, networkName: networkName}
+
+	info, err := cli.Info(context.Background())
+	if err != nil {
+		return nil, fmt.Errorf("failed to query daemon info: %w", err)
+	}
+	dm.defaultPlatform = v1.Platform{OS: info.OSType, Architecture: info.Architecture}
+
+	return dm, nil
+}
+
+// Platform returns the daemon's default platform, as cached at construction
+// time, so callers (e.g. the /system/platform route) can advertise what the
+// host actually supports.
+func (dm *DockerManager) Platform() v1.Platform {
+	return dm.defaultPlatform
 }
 
 // ListContainers lists containers. The 'all' parameter determines whether to list all containers or only running ones.
 func (dm *DockerManager) ListContainers(ctx context.Context, all bool) ([]ContainerInfo, error) {
 	containers, err := dm.cli.ContainerList(ctx, container.ListOptions{All: all})
 	if err != nil {
-		return nil, err
+		return nil, wrapClientErr(err)
+	}
+
+	return toContainerInfos(containers), nil
+}
+
+// ListContainersFiltered lists containers matching filter, translating it
+// into the equivalent Docker API filters.
+func (dm *DockerManager) ListContainersFiltered(ctx context.Context, all bool, filter ContainerFilter) ([]ContainerInfo, error) {
+	args := filters.NewArgs()
+	for k, v := range filter.Labels {
+		args.Add("label", fmt.Sprintf("%s=%s", k, v))
+	}
+	if filter.Name != "" {
+		args.Add("name", filter.Name)
 	}
+	if filter.Status != "" {
+		args.Add("status", filter.Status)
+	}
+	if filter.Ancestor != "" {
+		args.Add("ancestor", filter.Ancestor)
+	}
+
+	containers, err := dm.cli.ContainerList(ctx, container.ListOptions{All: all, Filters: args})
+	if err != nil {
+		return nil, wrapClientErr(err)
+	}
+
+	return toContainerInfos(containers), nil
+}
 
+func toContainerInfos(containers []container.Summary) []ContainerInfo {
 	var containerInfos []ContainerInfo
 	for _, c := range containers {
-		managedBy, ok := c.Labels["managed-by"]
-		if !ok {
-			managedBy = "unknown"
+		managedBy := "unknown"
+		if isManaged(c.Labels) {
+			managedBy = "hiveden"
 		}
 
 		var name string
@@ -56,18 +117,19 @@ func (dm *DockerManager) ListContainers(ctx context.Context, all bool) ([]Contai
 			name = strings.TrimPrefix(c.Names[0], "/")
 		}
 
-		info := ContainerInfo{
-			ID:        c.ID[:12],
-			Image:     c.Image,
-			ImageID:   c.ImageID,
-			Name:      name,
-			Uptime:    formatUptime(c.Created),
-			ManagedBy: managedBy,
-		}
-		containerInfos = append(containerInfos, info)
+		containerInfos = append(containerInfos, ContainerInfo{
+			ID:         c.ID[:12],
+			Image:      c.Image,
+			ImageID:    c.ImageID,
+			Name:       name,
+			Uptime:     formatUptime(c.Created),
+			ManagedBy:  managedBy,
+			Project:    c.Labels[LabelProject],
+			ConfigHash: c.Labels[LabelConfigHash],
+		})
 	}
 
-	return containerInfos, nil
+	return containerInfos
 }
 
 func formatUptime(createdAt int64) string {
@@ -92,71 +154,74 @@ func formatUptime(createdAt int64) string {
 }
 
 // CreateContainer creates a new container and attaches it to the hiveden network.
-func (dm *DockerManager) CreateContainer(ctx context.Context, imageName string, containerName string) (container.CreateResponse, error) {
+// platformSpec is an optional "os/arch[/variant]" selector (see ParsePlatform);
+// when empty the daemon's default platform is used. mounts are translated into
+// named-volume mounts, auto-creating any managed volume that doesn't exist yet.
+func (dm *DockerManager) CreateContainer(ctx context.Context, imageName string, containerName string, platformSpec string, mounts []Mount) (container.CreateResponse, error) {
 	networkExists, err := dm.NetworkExists(ctx, dm.networkName)
 	if err != nil {
 		return container.CreateResponse{}, fmt.Errorf("failed to check if network exists: %w", err)
 	}
 
 	if !networkExists {
-		return container.CreateResponse{}, fmt.Errorf("network %s does not exist", dm.networkName)
+		return container.CreateResponse{}, errdefs.NotFound(fmt.Errorf("network %s does not exist", dm.networkName))
 	}
 
-	return dm.cli.ContainerCreate(ctx, &container.Config{
-		Image: imageName,
-		Labels: map[string]string{
-			"managed-by": "hiveden",
-		},
-	}, &container.HostConfig{},
+	platform := dm.defaultPlatform
+	if platformSpec != "" {
+		requested, err := ParsePlatform(platformSpec)
+		if err != nil {
+			return container.CreateResponse{}, fmt.Errorf("invalid platform: %w", err)
+		}
+		if requested.OS != dm.defaultPlatform.OS {
+			return container.CreateResponse{}, fmt.Errorf("requested platform OS %q does not match daemon OS %q", requested.OS, dm.defaultPlatform.OS)
+		}
+		platform = *requested
+	}
+
+	hostConfig := &container.HostConfig{}
+	for _, m := range mounts {
+		exists, err := dm.VolumeExists(ctx, m.VolumeName)
+		if err != nil {
+			return container.CreateResponse{}, fmt.Errorf("failed to check if volume %s exists: %w", m.VolumeName, err)
+		}
+		if !exists {
+			if _, err := dm.CreateVolume(ctx, m.VolumeName, "", nil, nil); err != nil {
+				return container.CreateResponse{}, fmt.Errorf("failed to auto-create volume %s: %w", m.VolumeName, err)
+			}
+		}
+
+		hostConfig.Mounts = append(hostConfig.Mounts, mount.Mount{
+			Type:     mount.TypeVolume,
+			Source:   m.VolumeName,
+			Target:   m.Target,
+			ReadOnly: m.ReadOnly,
+		})
+	}
+
+	resp, err := dm.cli.ContainerCreate(ctx, &container.Config{
+		Image:  imageName,
+		Labels: dm.managedLabels(nil),
+	}, hostConfig,
 		&network.NetworkingConfig{
 			EndpointsConfig: map[string]*network.EndpointSettings{
 				dm.networkName: {},
 			},
-		}, nil, containerName)
+		}, &platform, containerName)
+	return resp, wrapClientErr(err)
 }
 
 // StartContainer starts a container.
 func (dm *DockerManager) StartContainer(ctx context.Context, containerID string) error {
-	return dm.cli.ContainerStart(ctx, containerID, container.StartOptions{})
+	return wrapClientErr(dm.cli.ContainerStart(ctx, containerID, container.StartOptions{}))
 }
 
 // StopContainer stops a container.
 func (dm *DockerManager) StopContainer(ctx context.Context, containerID string) error {
-	return dm.cli.ContainerStop(ctx, containerID, container.StopOptions{})
+	return wrapClientErr(dm.cli.ContainerStop(ctx, containerID, container.StopOptions{}))
 }
 
 // RemoveContainer removes a container.
 func (dm *DockerManager) RemoveContainer(ctx context.Context, containerID string) error {
-	return dm.cli.ContainerRemove(ctx, containerID, container.RemoveOptions{})
-}
-
-// ExportManagedContainers exports all containers managed by hiveden to a YAML file.
-func (dm *DockerManager) ExportManagedContainers(ctx context.Context, filePath string) error {
-	containers, err := dm.ListContainers(ctx, true)
-	if err != nil {
-		return err
-	}
-
-	var managedContainers []ContainerConfig
-	for _, c := range containers {
-		if c.ManagedBy == "hiveden" {
-			managedContainers = append(managedContainers, ContainerConfig{
-				Name:  c.Name,
-				Image: c.Image,
-			})
-		}
-	}
-
-	config := struct {
-		Containers []ContainerConfig `yaml:"containers"`
-	}{
-		Containers: managedContainers,
-	}
-
-	data, err := yaml.Marshal(&config)
-	if err != nil {
-		return fmt.Errorf("failed to marshal config: %w", err)
-	}
-
-	return os.WriteFile(filePath, data, 0644)
+	return wrapClientErr(dm.cli.ContainerRemove(ctx, containerID, container.RemoveOptions{}))
 }
@@ -6,9 +6,12 @@ import (
 	"io"
 	"testing"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
 	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
@@ -95,8 +98,64 @@ func (m *mockClient) ImagePull(ctx context.Context, ref string, options image.Pu
 	return nil, nil
 }
 
+func (m *mockClient) Info(ctx context.Context) (types.Info, error) {
+	return types.Info{OSType: "linux", Architecture: "x86_64"}, nil
+}
+
+func (m *mockClient) VolumeCreate(ctx context.Context, options volume.CreateOptions) (volume.Volume, error) {
+	return volume.Volume{Name: options.Name, Driver: options.Driver, Labels: options.Labels}, nil
+}
+
+func (m *mockClient) VolumeRemove(ctx context.Context, volumeID string, force bool) error {
+	return nil
+}
+
+func (m *mockClient) VolumeList(ctx context.Context, options volume.ListOptions) (volume.ListResponse, error) {
+	return volume.ListResponse{}, nil
+}
+
+func (m *mockClient) Events(ctx context.Context, options events.ListOptions) (<-chan events.Message, <-chan error) {
+	msgs := make(chan events.Message)
+	errs := make(chan error)
+	close(msgs)
+	close(errs)
+	return msgs, errs
+}
+
+func (m *mockClient) ContainerLogs(ctx context.Context, containerID string, options container.LogsOptions) (io.ReadCloser, error) {
+	return nil, nil
+}
+
+func (m *mockClient) ContainerStats(ctx context.Context, containerID string, stream bool) (container.StatsResponseReader, error) {
+	return container.StatsResponseReader{}, nil
+}
+
+func (m *mockClient) ContainerExecCreate(ctx context.Context, containerID string, options container.ExecOptions) (container.ExecCreateResponse, error) {
+	return container.ExecCreateResponse{ID: "exec-12345"}, nil
+}
+
+func (m *mockClient) ContainerExecAttach(ctx context.Context, execID string, options container.ExecStartOptions) (types.HijackedResponse, error) {
+	return types.HijackedResponse{}, nil
+}
+
+func (m *mockClient) ContainerExecResize(ctx context.Context, execID string, options container.ResizeOptions) error {
+	return nil
+}
+
+func (m *mockClient) ContainerInspect(ctx context.Context, containerID string) (container.InspectResponse, error) {
+	return container.InspectResponse{}, nil
+}
+
+func (m *mockClient) ContainerExecInspect(ctx context.Context, execID string) (container.ExecInspect, error) {
+	return container.ExecInspect{}, nil
+}
+
+func (m *mockClient) ImageInspect(ctx context.Context, imageID string) (image.InspectResponse, error) {
+	return image.InspectResponse{}, nil
+}
+
 func TestNewDockerManager(t *testing.T) {
-	dm, err := NewDockerManager("test-network")
+	dm, err := NewDockerManager("test-network", ConnectionConfig{})
 	if err != nil {
 		t.Fatalf("NewDockerManager() error = %v", err)
 	}
@@ -111,13 +170,13 @@ func TestNewDockerManager(t *testing.T) {
 func TestCreateContainer(t *testing.T) {
 	mock := &mockClient{}
 	dm := &DockerManager{cli: mock, networkName: "test-network"}
-	_, err := dm.CreateContainer(context.Background(), "test-image", "test-container")
+	_, err := dm.CreateContainer(context.Background(), "test-image", "test-container", "", nil)
 	if err != nil {
 		t.Fatalf("CreateContainer() error = %v", err)
 	}
 
-	if managedBy, ok := mock.lastCreateConfig.Labels["managed-by"]; !ok || managedBy != "hiveden" {
-		t.Errorf("expected managed-by label to be 'hiveden', got '%s'", managedBy)
+	if managed, ok := mock.lastCreateConfig.Labels[LabelManaged]; !ok || managed != "true" {
+		t.Errorf("expected %s label to be 'true', got '%s'", LabelManaged, managed)
 	}
 	if _, ok := mock.lastNetworkingConfig.EndpointsConfig["test-network"]; !ok {
 		t.Errorf("expected container to be attached to 'test-network'")
@@ -126,7 +185,7 @@ func TestCreateContainer(t *testing.T) {
 
 func TestCreateContainerError(t *testing.T) {
 	dm := &DockerManager{cli: &mockClient{createContainerErr: true}}
-	_, err := dm.CreateContainer(context.Background(), "test-image", "test-container")
+	_, err := dm.CreateContainer(context.Background(), "test-image", "test-container", "", nil)
 	if err == nil {
 		t.Fatal("expected an error, but got none")
 	}
@@ -195,13 +254,13 @@ func TestListContainersWithLabel(t *testing.T) {
 	mock := &mockClient{}
 	dm := &DockerManager{cli: mock}
 
-	// Mock a container with the managed-by label
+	// Mock a container with the managed label
 	mockContainer := container.Summary{
 		ID:      "1234567890ab",
 		Names:   []string{"/test-container"},
 		Image:   "test-image",
 		ImageID: "img-123",
-		Labels:  map[string]string{"managed-by": "hiveden"},
+		Labels:  map[string]string{LabelManaged: "true"},
 	}
 	mock.ContainerListFunc = func(ctx context.Context, options container.ListOptions) ([]container.Summary, error) {
 		return []container.Summary{mockContainer}, nil
@@ -217,7 +276,7 @@ func TestListContainersWithLabel(t *testing.T) {
 	}
 
 	if containers[0].ManagedBy != "hiveden" {
-		t.Errorf("expected managed-by to be 'hiveden', got '%s'", containers[0].ManagedBy)
+		t.Errorf("expected ManagedBy to be 'hiveden', got '%s'", containers[0].ManagedBy)
 	}
 }
 
@@ -231,7 +290,7 @@ func TestListContainersError(t *testing.T) {
 
 func TestCreateNetwork(t *testing.T) {
 	dm := &DockerManager{cli: &mockClient{}}
-	_, err := dm.CreateNetwork(context.Background(), "test-network")
+	_, err := dm.CreateNetwork(context.Background(), "test-network", nil)
 	if err != nil {
 		t.Fatalf("CreateNetwork() error = %v", err)
 	}
@@ -239,7 +298,7 @@ func TestCreateNetwork(t *testing.T) {
 
 func TestCreateNetworkError(t *testing.T) {
 	dm := &DockerManager{cli: &mockClient{createNetworkErr: true}}
-	_, err := dm.CreateNetwork(context.Background(), "test-network")
+	_, err := dm.CreateNetwork(context.Background(), "test-network", nil)
 	if err == nil {
 		t.Fatal("expected an error, but got none")
 	}
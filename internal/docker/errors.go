@@ -0,0 +1,32 @@
+package docker
+
+import (
+	dockererrdefs "github.com/docker/docker/errdefs"
+
+	"github.com/hiveden/hiveden/internal/errdefs"
+)
+
+// wrapClientErr classifies an error returned by the Docker client against
+// the daemon's own errdefs helpers and re-wraps it in hiveden's errdefs
+// taxonomy so the API layer can map it to a status code without knowing
+// anything about the Docker client library.
+func wrapClientErr(err error) error {
+	switch {
+	case err == nil:
+		return nil
+	case dockererrdefs.IsNotFound(err):
+		return errdefs.NotFound(err)
+	case dockererrdefs.IsConflict(err):
+		return errdefs.Conflict(err)
+	case dockererrdefs.IsInvalidParameter(err):
+		return errdefs.InvalidParameter(err)
+	case dockererrdefs.IsUnauthorized(err):
+		return errdefs.Unauthorized(err)
+	case dockererrdefs.IsForbidden(err):
+		return errdefs.Forbidden(err)
+	case dockererrdefs.IsUnavailable(err):
+		return errdefs.Unavailable(err)
+	default:
+		return err
+	}
+}
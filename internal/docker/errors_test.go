@@ -0,0 +1,69 @@
+package docker
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/hiveden/hiveden/internal/errdefs"
+)
+
+// These fakes implement the same marker interfaces the real
+// github.com/docker/docker/errdefs helpers check for, so wrapClientErr can
+// be tested without a live client error to trigger them.
+type fakeNotFoundErr struct{ error }
+
+func (fakeNotFoundErr) NotFound() {}
+
+type fakeConflictErr struct{ error }
+
+func (fakeConflictErr) Conflict() {}
+
+type fakeInvalidParameterErr struct{ error }
+
+func (fakeInvalidParameterErr) InvalidParameter() {}
+
+type fakeUnauthorizedErr struct{ error }
+
+func (fakeUnauthorizedErr) Unauthorized() {}
+
+type fakeForbiddenErr struct{ error }
+
+func (fakeForbiddenErr) Forbidden() {}
+
+type fakeUnavailableErr struct{ error }
+
+func (fakeUnavailableErr) Unavailable() {}
+
+func TestWrapClientErr(t *testing.T) {
+	base := errors.New("boom")
+
+	tests := []struct {
+		name       string
+		err        error
+		wantStatus int
+	}{
+		{"not found", fakeNotFoundErr{base}, http.StatusNotFound},
+		{"conflict", fakeConflictErr{base}, http.StatusConflict},
+		{"invalid parameter", fakeInvalidParameterErr{base}, http.StatusBadRequest},
+		{"unauthorized", fakeUnauthorizedErr{base}, http.StatusUnauthorized},
+		{"forbidden", fakeForbiddenErr{base}, http.StatusForbidden},
+		{"unavailable", fakeUnavailableErr{base}, http.StatusServiceUnavailable},
+		{"unclassified", base, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := wrapClientErr(tt.err)
+			if status := errdefs.StatusCode(got); status != tt.wantStatus {
+				t.Errorf("StatusCode(wrapClientErr(%v)) = %d, want %d", tt.err, status, tt.wantStatus)
+			}
+		})
+	}
+}
+
+func TestWrapClientErrNil(t *testing.T) {
+	if err := wrapClientErr(nil); err != nil {
+		t.Errorf("wrapClientErr(nil) = %v, want nil", err)
+	}
+}
@@ -0,0 +1,130 @@
+package docker
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/filters"
+)
+
+// eventRingSize bounds how many past events a late subscriber can catch up on.
+const eventRingSize = 256
+
+// Watch returns a channel of hiveden-managed lifecycle events (container,
+// network, and volume start/stop/die/... actions). The underlying
+// subscription to the daemon's event stream is started lazily on the first
+// call and shared by all subscribers via an internal fan-out goroutine that
+// reconnects with exponential backoff if the stream errors out.
+func (dm *DockerManager) Watch(ctx context.Context) (<-chan Event, error) {
+	dm.watchOnce.Do(func() {
+		dm.watchSubs = make(map[chan Event]struct{})
+		dm.watchRing = make([]Event, 0, eventRingSize)
+		go dm.runEventLoop()
+	})
+
+	ch := make(chan Event, eventRingSize)
+
+	dm.watchMu.Lock()
+	for _, e := range dm.watchRing {
+		ch <- e
+	}
+	dm.watchSubs[ch] = struct{}{}
+	dm.watchMu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+		dm.watchMu.Lock()
+		delete(dm.watchSubs, ch)
+		close(ch)
+		dm.watchMu.Unlock()
+	}()
+
+	return ch, nil
+}
+
+func (dm *DockerManager) runEventLoop() {
+	backoff := time.Second
+	const maxBackoff = 30 * time.Second
+
+	for {
+		msgs, errs := dm.cli.Events(context.Background(), events.ListOptions{
+			Filters: filters.NewArgs(filters.Arg("label", LabelManaged+"=true")),
+		})
+
+		streamErr := dm.consumeEvents(msgs, errs)
+		if streamErr == nil {
+			return
+		}
+
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > maxBackoff {
+			backoff = maxBackoff
+		}
+	}
+}
+
+// consumeEvents drains a single Events() stream, publishing each message to
+// subscribers until the stream ends or errors.
+func (dm *DockerManager) consumeEvents(msgs <-chan events.Message, errs <-chan error) error {
+	for {
+		select {
+		case msg, ok := <-msgs:
+			if !ok {
+				return nil
+			}
+			dm.publishEvent(decodeEvent(msg))
+		case err, ok := <-errs:
+			if !ok {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+func decodeEvent(msg events.Message) Event {
+	attrs := map[string]string{}
+	for k, v := range msg.Actor.Attributes {
+		attrs[k] = v
+	}
+
+	return Event{
+		Kind:       string(msg.Type),
+		Action:     string(msg.Action),
+		ID:         msg.Actor.ID,
+		Name:       attrs["name"],
+		Time:       time.Unix(0, msg.TimeNano),
+		Attributes: attrs,
+	}
+}
+
+func (dm *DockerManager) publishEvent(e Event) {
+	dm.watchMu.Lock()
+	defer dm.watchMu.Unlock()
+
+	dm.watchRing = append(dm.watchRing, e)
+	if len(dm.watchRing) > eventRingSize {
+		dm.watchRing = dm.watchRing[len(dm.watchRing)-eventRingSize:]
+	}
+
+	for sub := range dm.watchSubs {
+		select {
+		case sub <- e:
+		default:
+			// Slow subscriber; drop the event rather than block the fan-out.
+		}
+	}
+}
+
+// watchState holds the lazily-initialized fan-out state for Watch. It is
+// embedded in DockerManager, so its fields are promoted onto DockerManager
+// and DockerManager stays the single entry point for callers.
+type watchState struct {
+	watchOnce sync.Once
+	watchMu   sync.Mutex
+	watchSubs map[chan Event]struct{}
+	watchRing []Event
+}
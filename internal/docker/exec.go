@@ -0,0 +1,79 @@
+package docker
+
+import (
+	"context"
+	"io"
+	"net"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// LogOptions controls which slice of a container's log output is returned.
+type LogOptions struct {
+	Follow     bool
+	Tail       string
+	Since      string
+	Until      string
+	Timestamps bool
+}
+
+// ExecConfig describes a command to run inside a running container via exec.
+type ExecConfig struct {
+	Cmd          []string
+	Env          []string
+	Tty          bool
+	AttachStdin  bool
+	AttachStdout bool
+	AttachStderr bool
+}
+
+// ContainerLogs returns the (optionally following) log stream for a container.
+// Callers are responsible for closing the returned ReadCloser.
+func (dm *DockerManager) ContainerLogs(ctx context.Context, containerID string, opts LogOptions) (io.ReadCloser, error) {
+	rc, err := dm.cli.ContainerLogs(ctx, containerID, container.LogsOptions{
+		ShowStdout: true,
+		ShowStderr: true,
+		Follow:     opts.Follow,
+		Tail:       opts.Tail,
+		Since:      opts.Since,
+		Until:      opts.Until,
+		Timestamps: opts.Timestamps,
+	})
+	return rc, wrapClientErr(err)
+}
+
+// ExecCreate creates an exec instance inside a running container and returns
+// its ID for use with ExecStart.
+func (dm *DockerManager) ExecCreate(ctx context.Context, containerID string, cfg ExecConfig) (string, error) {
+	resp, err := dm.cli.ContainerExecCreate(ctx, containerID, container.ExecOptions{
+		Cmd:          cfg.Cmd,
+		Env:          cfg.Env,
+		Tty:          cfg.Tty,
+		AttachStdin:  cfg.AttachStdin,
+		AttachStdout: cfg.AttachStdout,
+		AttachStderr: cfg.AttachStderr,
+	})
+	if err != nil {
+		return "", wrapClientErr(err)
+	}
+	return resp.ID, nil
+}
+
+// ExecStart attaches to an already-created exec instance, returning the raw
+// hijacked connection. Callers demultiplex stdout/stderr with stdcopy.StdCopy
+// when the exec was created without a TTY.
+func (dm *DockerManager) ExecStart(ctx context.Context, execID string) (net.Conn, error) {
+	resp, err := dm.cli.ContainerExecAttach(ctx, execID, container.ExecStartOptions{})
+	if err != nil {
+		return nil, wrapClientErr(err)
+	}
+	return resp.Conn, nil
+}
+
+// ExecResize resizes the TTY of a running exec instance.
+func (dm *DockerManager) ExecResize(ctx context.Context, execID string, height, width uint) error {
+	return wrapClientErr(dm.cli.ContainerExecResize(ctx, execID, container.ResizeOptions{
+		Height: height,
+		Width:  width,
+	}))
+}
@@ -0,0 +1,192 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/go-connections/nat"
+	"gopkg.in/yaml.v2"
+)
+
+// ExportOptions controls what GenerateManifest/ExportManagedContainers
+// include and how the result is serialized.
+type ExportOptions struct {
+	// IncludeStopped exports every hiveden-managed container, not just
+	// running ones.
+	IncludeStopped bool
+	// Format is "yaml" (the default), "json", or "compose" (a Compose v3
+	// file, in the spirit of `podman generate kube`).
+	Format string
+}
+
+// ExportManagedContainers inspects every running hiveden-managed container
+// (stopped ones too with opts.IncludeStopped) and writes a manifest
+// reproducing its full configuration — env, mounts,
+// ports, labels, restart policy, command, and entrypoint — so that
+// `hiveden play -f <file>` on another host recreates the same deployment.
+func (dm *DockerManager) ExportManagedContainers(ctx context.Context, filePath string, opts ExportOptions) error {
+	manifest, err := dm.GenerateManifest(ctx, opts.IncludeStopped)
+	if err != nil {
+		return err
+	}
+
+	var data []byte
+	switch opts.Format {
+	case "", "yaml":
+		data, err = yaml.Marshal(manifest)
+	case "json":
+		data, err = json.MarshalIndent(manifest, "", "  ")
+	case "compose":
+		data, err = yaml.Marshal(toComposeFile(manifest))
+	default:
+		return fmt.Errorf("unknown export format %q, expected yaml, json, or compose", opts.Format)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+
+	return os.WriteFile(filePath, data, 0644)
+}
+
+// GenerateManifest inspects every hiveden-managed container — including
+// stopped ones only if includeStopped is set — and reconstructs the
+// Manifest that reproduces it, suitable for Apply on another host.
+// Entries are sorted by name so the result diffs cleanly across runs.
+func (dm *DockerManager) GenerateManifest(ctx context.Context, includeStopped bool) (*Manifest, error) {
+	containers, err := dm.ListContainersFiltered(ctx, true, ContainerFilter{
+		Labels: map[string]string{LabelManaged: "true"},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list managed containers: %w", err)
+	}
+
+	volumeNames := map[string]bool{}
+	var manifest Manifest
+	for _, c := range containers {
+		info, err := dm.cli.ContainerInspect(ctx, c.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to inspect container %s: %w", c.Name, err)
+		}
+		if !includeStopped && (info.State == nil || !info.State.Running) {
+			continue
+		}
+
+		cfg := containerConfigFromInspect(info)
+		for _, v := range cfg.Volumes {
+			name, _, _ := strings.Cut(v, ":")
+			volumeNames[name] = true
+		}
+		manifest.Containers = append(manifest.Containers, cfg)
+	}
+	sort.Slice(manifest.Containers, func(i, j int) bool {
+		return manifest.Containers[i].Name < manifest.Containers[j].Name
+	})
+
+	if len(manifest.Containers) > 0 {
+		manifest.Networks = []NetworkConfig{{Name: dm.networkName}}
+	}
+
+	for name := range volumeNames {
+		manifest.Volumes = append(manifest.Volumes, VolumeConfig{Name: name})
+	}
+	sort.Slice(manifest.Volumes, func(i, j int) bool { return manifest.Volumes[i].Name < manifest.Volumes[j].Name })
+
+	return &manifest, nil
+}
+
+// containerConfigFromInspect reverses container.InspectResponse back into
+// the ContainerConfig that would have produced it, the inverse of
+// createSpecContainer.
+func containerConfigFromInspect(info container.InspectResponse) ContainerConfig {
+	cfg := ContainerConfig{Name: strings.TrimPrefix(info.Name, "/")}
+
+	if info.Config != nil {
+		cfg.Image = info.Config.Image
+		cfg.Env = info.Config.Env
+		cfg.Command = info.Config.Cmd
+		cfg.Entrypoint = info.Config.Entrypoint
+		cfg.Labels = labelsWithoutManaged(info.Config.Labels)
+		cfg.Healthcheck = healthcheckConfigFromDocker(info.Config.Healthcheck)
+	}
+
+	if info.HostConfig != nil {
+		cfg.Restart = string(info.HostConfig.RestartPolicy.Name)
+		cfg.MaxRetries = info.HostConfig.RestartPolicy.MaximumRetryCount
+		cfg.Ports = portsFromBindings(info.HostConfig.PortBindings)
+	}
+
+	for _, m := range info.Mounts {
+		if m.Type != mount.TypeVolume {
+			continue
+		}
+		spec := fmt.Sprintf("%s:%s", m.Name, m.Destination)
+		if !m.RW {
+			spec += ":ro"
+		}
+		cfg.Volumes = append(cfg.Volumes, spec)
+	}
+	sort.Strings(cfg.Volumes)
+
+	return cfg
+}
+
+// labelsWithoutManaged strips hiveden's own bookkeeping labels, leaving
+// only the ones a user set via ContainerConfig.Labels.
+func labelsWithoutManaged(labels map[string]string) map[string]string {
+	if len(labels) == 0 {
+		return nil
+	}
+
+	out := map[string]string{}
+	for k, v := range labels {
+		if k == LabelManaged || k == LabelProject || k == LabelConfigHash {
+			continue
+		}
+		out[k] = v
+	}
+	if len(out) == 0 {
+		return nil
+	}
+	return out
+}
+
+// portsFromBindings reverses a nat.PortMap back into "hostPort:containerPort/proto" specs.
+func portsFromBindings(bindings nat.PortMap) []string {
+	var ports []string
+	for port, bs := range bindings {
+		for _, b := range bs {
+			if b.HostPort == "" {
+				continue
+			}
+			ports = append(ports, fmt.Sprintf("%s:%s/%s", b.HostPort, port.Port(), port.Proto()))
+		}
+	}
+	sort.Strings(ports)
+	return ports
+}
+
+// healthcheckConfigFromDocker reverses a container.HealthConfig back into
+// the YAML-friendly HealthcheckConfig, or nil if none is configured.
+func healthcheckConfigFromDocker(hc *container.HealthConfig) *HealthcheckConfig {
+	if hc == nil || len(hc.Test) == 0 {
+		return nil
+	}
+
+	cfg := &HealthcheckConfig{Test: hc.Test, Retries: hc.Retries}
+	if hc.Interval != 0 {
+		cfg.Interval = hc.Interval.String()
+	}
+	if hc.Timeout != 0 {
+		cfg.Timeout = hc.Timeout.String()
+	}
+	if hc.StartPeriod != 0 {
+		cfg.StartPeriod = hc.StartPeriod.String()
+	}
+	return cfg
+}
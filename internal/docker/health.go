@@ -0,0 +1,162 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/pkg/stdcopy"
+)
+
+// HealthcheckConfig describes how to probe a container's health, mirroring
+// container.HealthConfig with YAML-friendly duration strings (e.g. "30s").
+type HealthcheckConfig struct {
+	Test        []string `yaml:"test,omitempty"`
+	Interval    string   `yaml:"interval,omitempty"`
+	Timeout     string   `yaml:"timeout,omitempty"`
+	Retries     int      `yaml:"retries,omitempty"`
+	StartPeriod string   `yaml:"start_period,omitempty"`
+}
+
+// toDockerHealthConfig converts cfg into the container.HealthConfig Docker
+// expects, returning nil when cfg has no test command (Docker treats a nil
+// HealthConfig as "no healthcheck").
+func (cfg *HealthcheckConfig) toDockerHealthConfig() (*container.HealthConfig, error) {
+	if cfg == nil || len(cfg.Test) == 0 {
+		return nil, nil
+	}
+
+	health := &container.HealthConfig{Test: cfg.Test, Retries: cfg.Retries}
+
+	var err error
+	if health.Interval, err = parseOptionalDuration(cfg.Interval); err != nil {
+		return nil, fmt.Errorf("invalid healthcheck interval: %w", err)
+	}
+	if health.Timeout, err = parseOptionalDuration(cfg.Timeout); err != nil {
+		return nil, fmt.Errorf("invalid healthcheck timeout: %w", err)
+	}
+	if health.StartPeriod, err = parseOptionalDuration(cfg.StartPeriod); err != nil {
+		return nil, fmt.Errorf("invalid healthcheck start_period: %w", err)
+	}
+
+	return health, nil
+}
+
+func parseOptionalDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+	return time.ParseDuration(s)
+}
+
+// HealthcheckResult is one probe outcome, whether run natively by the daemon
+// or forced via RunHealthcheck.
+type HealthcheckResult struct {
+	Start    time.Time
+	End      time.Time
+	ExitCode int
+	Output   string
+}
+
+// RunHealthcheck executes a container's configured healthcheck command
+// immediately via exec, instead of waiting for the daemon's own probe
+// interval, and records the outcome for later retrieval with GetHealth.
+func (dm *DockerManager) RunHealthcheck(ctx context.Context, containerID string) (HealthcheckResult, error) {
+	info, err := dm.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return HealthcheckResult{}, wrapClientErr(err)
+	}
+	if info.Config == nil || info.Config.Healthcheck == nil || len(info.Config.Healthcheck.Test) == 0 {
+		return HealthcheckResult{}, fmt.Errorf("container %s has no healthcheck configured", containerID)
+	}
+
+	test := info.Config.Healthcheck.Test
+	var cmd []string
+	switch {
+	case test[0] == "CMD-SHELL":
+		cmd = []string{"/bin/sh", "-c", test[1]}
+	case test[0] == "CMD":
+		cmd = test[1:]
+	default:
+		cmd = test
+	}
+
+	start := time.Now()
+	execID, err := dm.ExecCreate(ctx, containerID, ExecConfig{Cmd: cmd, AttachStdout: true, AttachStderr: true})
+	if err != nil {
+		return HealthcheckResult{}, err
+	}
+
+	conn, err := dm.ExecStart(ctx, execID)
+	if err != nil {
+		return HealthcheckResult{}, err
+	}
+	defer conn.Close()
+
+	var out bytes.Buffer
+	if _, err := stdcopy.StdCopy(&out, &out, conn); err != nil {
+		return HealthcheckResult{}, fmt.Errorf("failed to read healthcheck output: %w", err)
+	}
+
+	inspect, err := dm.cli.ContainerExecInspect(ctx, execID)
+	if err != nil {
+		return HealthcheckResult{}, fmt.Errorf("failed to inspect healthcheck exec: %w", err)
+	}
+
+	result := HealthcheckResult{Start: start, End: time.Now(), ExitCode: inspect.ExitCode, Output: out.String()}
+	dm.recordHealth(containerID, result)
+	return result, nil
+}
+
+// GetHealth returns up to n of a container's most recent healthcheck
+// results, newest first, combining the daemon's own probe history with any
+// results recorded by RunHealthcheck.
+func (dm *DockerManager) GetHealth(ctx context.Context, containerID string, n int) ([]HealthcheckResult, error) {
+	info, err := dm.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return nil, wrapClientErr(err)
+	}
+
+	var results []HealthcheckResult
+	if info.State != nil && info.State.Health != nil {
+		for _, log := range info.State.Health.Log {
+			results = append(results, HealthcheckResult{
+				Start:    log.Start,
+				End:      log.End,
+				ExitCode: log.ExitCode,
+				Output:   log.Output,
+			})
+		}
+	}
+
+	dm.healthMu.Lock()
+	results = append(results, dm.healthLog[containerID]...)
+	dm.healthMu.Unlock()
+
+	sort.Slice(results, func(i, j int) bool { return results[i].Start.After(results[j].Start) })
+	if len(results) > n {
+		results = results[:n]
+	}
+	return results, nil
+}
+
+// recordHealth appends a forced RunHealthcheck result to the container's
+// in-memory log, capped at maxHealthLog entries per container.
+func (dm *DockerManager) recordHealth(containerID string, result HealthcheckResult) {
+	const maxHealthLog = 20
+
+	dm.healthMu.Lock()
+	defer dm.healthMu.Unlock()
+
+	if dm.healthLog == nil {
+		dm.healthLog = make(map[string][]HealthcheckResult)
+	}
+	log := append(dm.healthLog[containerID], result)
+	if len(log) > maxHealthLog {
+		log = log[len(log)-maxHealthLog:]
+	}
+	dm.healthLog[containerID] = log
+}
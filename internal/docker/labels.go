@@ -0,0 +1,49 @@
+package docker
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+)
+
+// Label keys stamped onto every container and network DockerManager creates,
+// so managed resources can be told apart from ones a user created by hand
+// and filtered on reliably even across hiveden restarts (ManagedBy on
+// ContainerInfo is derived from these, not tracked separately).
+const (
+	// LabelManaged marks a resource as created and owned by DockerManager.
+	LabelManaged = "hiveden.managed"
+	// LabelProject groups resources created for the same network/project.
+	LabelProject = "hiveden.project"
+	// LabelConfigHash stores a hash of the ContainerConfig a container was
+	// created from, so Apply can detect drift beyond just the image.
+	LabelConfigHash = "hiveden.config-hash"
+)
+
+// managedLabels returns the base label set every hiveden-created resource
+// carries, merged with any resource-specific extras.
+func (dm *DockerManager) managedLabels(extra map[string]string) map[string]string {
+	labels := map[string]string{
+		LabelManaged: "true",
+		LabelProject: dm.networkName,
+	}
+	for k, v := range extra {
+		labels[k] = v
+	}
+	return labels
+}
+
+// isManaged reports whether a label set carries hiveden's managed marker.
+func isManaged(labels map[string]string) bool {
+	return labels[LabelManaged] == "true"
+}
+
+// configHash returns a stable hex-encoded SHA-256 hash of the fields of cfg
+// that matter for deciding whether a running container still matches its
+// manifest entry.
+func configHash(cfg ContainerConfig) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\x00%v\x00%v\x00%v\x00%v\x00%v\x00%v\x00%s\x00%d\x00%v\x00%+v",
+		cfg.Image, cfg.Env, cfg.Ports, cfg.Labels, cfg.Volumes, cfg.Command, cfg.Entrypoint, cfg.Restart, cfg.MaxRetries, cfg.DependsOn, cfg.Healthcheck)
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,45 @@
+package docker
+
+import "testing"
+
+func TestConfigHashStable(t *testing.T) {
+	cfg := ContainerConfig{
+		Image: "nginx:latest",
+		Env:   []string{"FOO=bar"},
+		Ports: []string{"8080:80"},
+	}
+
+	if configHash(cfg) != configHash(cfg) {
+		t.Error("configHash() is not stable across calls with the same input")
+	}
+}
+
+func TestConfigHashDetectsDrift(t *testing.T) {
+	base := ContainerConfig{Image: "nginx:latest"}
+	hash := configHash(base)
+
+	tests := []struct {
+		name string
+		cfg  ContainerConfig
+	}{
+		{"image", ContainerConfig{Image: "nginx:1.25"}},
+		{"env", ContainerConfig{Image: "nginx:latest", Env: []string{"FOO=bar"}}},
+		{"ports", ContainerConfig{Image: "nginx:latest", Ports: []string{"8080:80"}}},
+		{"labels", ContainerConfig{Image: "nginx:latest", Labels: map[string]string{"team": "infra"}}},
+		{"volumes", ContainerConfig{Image: "nginx:latest", Volumes: []string{"data:/data"}}},
+		{"command", ContainerConfig{Image: "nginx:latest", Command: []string{"nginx", "-g", "daemon off;"}}},
+		{"entrypoint", ContainerConfig{Image: "nginx:latest", Entrypoint: []string{"/entrypoint.sh"}}},
+		{"restart", ContainerConfig{Image: "nginx:latest", Restart: "always"}},
+		{"max retries", ContainerConfig{Image: "nginx:latest", MaxRetries: 3}},
+		{"depends_on", ContainerConfig{Image: "nginx:latest", DependsOn: []string{"db"}}},
+		{"healthcheck", ContainerConfig{Image: "nginx:latest", Healthcheck: &HealthcheckConfig{Test: []string{"CMD", "curl", "-f", "localhost"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if configHash(tt.cfg) == hash {
+				t.Errorf("configHash() did not change when %s changed", tt.name)
+			}
+		})
+	}
+}
@@ -2,36 +2,65 @@ package docker
 
 import (
 	"context"
+	"fmt"
 
+	"github.com/docker/docker/api/types/filters"
 	"github.com/docker/docker/api/types/network"
 )
 
-// CreateNetwork creates a new network.
-func (dm *DockerManager) CreateNetwork(ctx context.Context, networkName string) (network.CreateResponse, error) {
-	return dm.cli.NetworkCreate(ctx, networkName, network.CreateOptions{})
+// CreateNetwork creates a new network, stamping it with hiveden's managed
+// labels merged with any extras the caller supplied (mirroring
+// CreateContainer, so every network created through this method — not just
+// ones created via `hiveden play` — is reliably found by prune/filter/export).
+func (dm *DockerManager) CreateNetwork(ctx context.Context, networkName string, labels map[string]string) (network.CreateResponse, error) {
+	resp, err := dm.cli.NetworkCreate(ctx, networkName, network.CreateOptions{Labels: dm.managedLabels(labels)})
+	return resp, wrapClientErr(err)
 }
 
 // RemoveNetwork removes a network.
 func (dm *DockerManager) RemoveNetwork(ctx context.Context, networkID string) error {
-	return dm.cli.NetworkRemove(ctx, networkID)
+	return wrapClientErr(dm.cli.NetworkRemove(ctx, networkID))
 }
 
 // ListNetworks lists all networks.
 func (dm *DockerManager) ListNetworks(ctx context.Context) ([]NetworkInfo, error) {
 	networks, err := dm.cli.NetworkList(ctx, network.ListOptions{})
 	if err != nil {
-		return nil, err
+		return nil, wrapClientErr(err)
 	}
 
+	return toNetworkInfos(networks), nil
+}
+
+// ListNetworksFiltered lists networks matching filter, translating it into
+// the equivalent Docker API filters.
+func (dm *DockerManager) ListNetworksFiltered(ctx context.Context, filter NetworkFilter) ([]NetworkInfo, error) {
+	args := filters.NewArgs()
+	for k, v := range filter.Labels {
+		args.Add("label", fmt.Sprintf("%s=%s", k, v))
+	}
+	if filter.Name != "" {
+		args.Add("name", filter.Name)
+	}
+
+	networks, err := dm.cli.NetworkList(ctx, network.ListOptions{Filters: args})
+	if err != nil {
+		return nil, wrapClientErr(err)
+	}
+
+	return toNetworkInfos(networks), nil
+}
+
+func toNetworkInfos(networks []network.Summary) []NetworkInfo {
 	var networkInfos []NetworkInfo
 	for _, n := range networks {
 		networkInfos = append(networkInfos, NetworkInfo{
-			ID:   n.ID,
-			Name: n.Name,
+			ID:     n.ID,
+			Name:   n.Name,
+			Labels: n.Labels,
 		})
 	}
-
-	return networkInfos, nil
+	return networkInfos
 }
 
 // NetworkExists checks if a network exists.
@@ -0,0 +1,27 @@
+package docker
+
+import (
+	"fmt"
+	"strings"
+
+	v1 "github.com/opencontainers/image-spec/specs-go/v1"
+)
+
+// ParsePlatform parses a platform specifier of the form "os/arch[/variant]",
+// equivalent in spirit to containerd's platforms.Parse, but scoped to the
+// subset hiveden actually needs to pass through to cli.ContainerCreate.
+func ParsePlatform(spec string) (*v1.Platform, error) {
+	if spec == "" {
+		return nil, fmt.Errorf("empty platform spec")
+	}
+
+	parts := strings.Split(spec, "/")
+	switch len(parts) {
+	case 2:
+		return &v1.Platform{OS: parts[0], Architecture: parts[1]}, nil
+	case 3:
+		return &v1.Platform{OS: parts[0], Architecture: parts[1], Variant: parts[2]}, nil
+	default:
+		return nil, fmt.Errorf("invalid platform spec %q, expected os/arch[/variant]", spec)
+	}
+}
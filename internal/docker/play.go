@@ -0,0 +1,488 @@
+package docker
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/mount"
+	"github.com/docker/docker/api/types/network"
+	"github.com/docker/go-connections/nat"
+	"gopkg.in/yaml.v2"
+
+	"github.com/hiveden/hiveden/internal/errdefs"
+)
+
+// PlayOptions controls how Apply reconciles the host to a manifest.
+type PlayOptions struct {
+	// Prune removes any hiveden-managed network, volume, or container that
+	// isn't present in the manifest.
+	Prune bool
+}
+
+// PlannedAction describes one step Apply would take to reconcile the host to
+// a manifest: create a missing resource, reuse one that already matches by
+// name, recreate one whose configuration has drifted, or (with Prune) remove one
+// that's no longer in the manifest.
+type PlannedAction struct {
+	Kind   string // "network", "volume", or "container"
+	Name   string
+	Action string // "create", "reuse", "recreate", or "remove"
+}
+
+// ParseManifest parses a play manifest, which may be a single YAML document
+// or a "---"-separated multi-document stream, into its combined networks,
+// volumes, and containers.
+func ParseManifest(data []byte) (*Manifest, error) {
+	var manifest Manifest
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var doc Manifest
+		if err := decoder.Decode(&doc); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, fmt.Errorf("failed to unmarshal manifest: %w", err)
+		}
+		manifest.Networks = append(manifest.Networks, doc.Networks...)
+		manifest.Volumes = append(manifest.Volumes, doc.Volumes...)
+		manifest.Containers = append(manifest.Containers, doc.Containers...)
+	}
+
+	return &manifest, nil
+}
+
+// Plan computes the actions Apply would take to reconcile the host to the
+// given manifest, without making any changes.
+func (dm *DockerManager) Plan(ctx context.Context, manifest *Manifest, opts PlayOptions) ([]PlannedAction, error) {
+	if _, err := orderContainers(manifest.Containers); err != nil {
+		return nil, err
+	}
+
+	var actions []PlannedAction
+
+	existingNetworks, err := dm.ListNetworks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list networks: %w", err)
+	}
+	managedNetworks := make(map[string]bool, len(existingNetworks))
+	networkExists := make(map[string]bool, len(existingNetworks))
+	for _, n := range existingNetworks {
+		networkExists[n.Name] = true
+		if isManaged(n.Labels) {
+			managedNetworks[n.Name] = true
+		}
+	}
+	desiredNetworks := make(map[string]bool, len(manifest.Networks))
+	for _, n := range manifest.Networks {
+		desiredNetworks[n.Name] = true
+		actions = append(actions, PlannedAction{"network", n.Name, reuseOrCreate(networkExists[n.Name])})
+	}
+	if opts.Prune {
+		for name := range managedNetworks {
+			if !desiredNetworks[name] {
+				actions = append(actions, PlannedAction{"network", name, "remove"})
+			}
+		}
+	}
+
+	existingVolumes, err := dm.ListVolumes(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list volumes: %w", err)
+	}
+	managedVolumes := make(map[string]bool, len(existingVolumes))
+	volumeExists := make(map[string]bool, len(existingVolumes))
+	for _, v := range existingVolumes {
+		volumeExists[v.Name] = true
+		if isManaged(v.Labels) {
+			managedVolumes[v.Name] = true
+		}
+	}
+	desiredVolumes := make(map[string]bool, len(manifest.Volumes))
+	for _, v := range manifest.Volumes {
+		desiredVolumes[v.Name] = true
+		actions = append(actions, PlannedAction{"volume", v.Name, reuseOrCreate(volumeExists[v.Name])})
+	}
+	if opts.Prune {
+		for name := range managedVolumes {
+			if !desiredVolumes[name] {
+				actions = append(actions, PlannedAction{"volume", name, "remove"})
+			}
+		}
+	}
+
+	existingContainers, err := dm.ListContainers(ctx, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+	existingByName := make(map[string]ContainerInfo, len(existingContainers))
+	for _, c := range existingContainers {
+		if c.ManagedBy == "hiveden" {
+			existingByName[c.Name] = c
+		}
+	}
+	desired := make(map[string]bool, len(manifest.Containers))
+	for _, c := range manifest.Containers {
+		desired[c.Name] = true
+		existing, ok := existingByName[c.Name]
+		switch {
+		case !ok:
+			actions = append(actions, PlannedAction{"container", c.Name, "create"})
+		case existing.ConfigHash != configHash(c):
+			actions = append(actions, PlannedAction{"container", c.Name, "recreate"})
+		default:
+			actions = append(actions, PlannedAction{"container", c.Name, "reuse"})
+		}
+	}
+	if opts.Prune {
+		for name := range existingByName {
+			if !desired[name] {
+				actions = append(actions, PlannedAction{"container", name, "remove"})
+			}
+		}
+	}
+
+	return actions, nil
+}
+
+// reuseOrCreate is the two-way version of the create/recreate/reuse decision
+// used when a resource has no drift condition to check, just presence.
+func reuseOrCreate(exists bool) string {
+	if exists {
+		return "reuse"
+	}
+	return "create"
+}
+
+// Apply reconciles the host to the given manifest: creating missing
+// networks, volumes, and containers, reusing ones that already match by
+// name, recreating containers whose configuration has drifted, starting containers
+// in depends_on order, and — with opts.Prune — removing any hiveden-managed
+// resource no longer present in the manifest. It is safe to call repeatedly
+// with the same manifest.
+func (dm *DockerManager) Apply(ctx context.Context, manifest *Manifest, opts PlayOptions) error {
+	ordered, err := orderContainers(manifest.Containers)
+	if err != nil {
+		return err
+	}
+
+	if err := dm.applyNetworks(ctx, manifest.Networks, opts.Prune); err != nil {
+		return err
+	}
+	if err := dm.applyVolumes(ctx, manifest.Volumes, opts.Prune); err != nil {
+		return err
+	}
+	if err := dm.applyContainers(ctx, ordered, opts.Prune); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (dm *DockerManager) applyNetworks(ctx context.Context, networks []NetworkConfig, prune bool) error {
+	existing, err := dm.ListNetworks(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list networks: %w", err)
+	}
+	existingByName := make(map[string]NetworkInfo, len(existing))
+	for _, n := range existing {
+		existingByName[n.Name] = n
+	}
+
+	desired := make(map[string]bool, len(networks))
+	for _, n := range networks {
+		desired[n.Name] = true
+		if _, ok := existingByName[n.Name]; ok {
+			continue
+		}
+		if _, err := dm.CreateNetwork(ctx, n.Name, nil); err != nil {
+			return fmt.Errorf("failed to create network %s: %w", n.Name, err)
+		}
+	}
+
+	if prune {
+		for name, n := range existingByName {
+			if desired[name] || !isManaged(n.Labels) {
+				continue
+			}
+			if err := dm.RemoveNetwork(ctx, n.ID); err != nil {
+				return fmt.Errorf("failed to prune network %s: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (dm *DockerManager) applyVolumes(ctx context.Context, volumes []VolumeConfig, prune bool) error {
+	existing, err := dm.ListVolumes(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list volumes: %w", err)
+	}
+	existingByName := make(map[string]VolumeInfo, len(existing))
+	for _, v := range existing {
+		existingByName[v.Name] = v
+	}
+
+	desired := make(map[string]bool, len(volumes))
+	for _, v := range volumes {
+		desired[v.Name] = true
+		if _, ok := existingByName[v.Name]; ok {
+			continue
+		}
+		if _, err := dm.CreateVolume(ctx, v.Name, v.Driver, v.DriverOpts, v.Labels); err != nil {
+			return fmt.Errorf("failed to create volume %s: %w", v.Name, err)
+		}
+	}
+
+	if prune {
+		for name, v := range existingByName {
+			if desired[name] || !isManaged(v.Labels) {
+				continue
+			}
+			if err := dm.RemoveVolume(ctx, name, false); err != nil {
+				return fmt.Errorf("failed to prune volume %s: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+func (dm *DockerManager) applyContainers(ctx context.Context, containers []ContainerConfig, prune bool) error {
+	existing, err := dm.ListContainers(ctx, true)
+	if err != nil {
+		return fmt.Errorf("failed to list containers: %w", err)
+	}
+	existingByName := make(map[string]ContainerInfo, len(existing))
+	for _, c := range existing {
+		if c.ManagedBy == "hiveden" {
+			existingByName[c.Name] = c
+		}
+	}
+
+	desired := make(map[string]bool, len(containers))
+	for _, cfg := range containers {
+		desired[cfg.Name] = true
+
+		if existingC, ok := existingByName[cfg.Name]; ok {
+			if existingC.ConfigHash == configHash(cfg) {
+				continue
+			}
+			if err := dm.StopContainer(ctx, existingC.ID); err != nil {
+				return fmt.Errorf("failed to stop stale container %s: %w", cfg.Name, err)
+			}
+			if err := dm.RemoveContainer(ctx, existingC.ID); err != nil {
+				return fmt.Errorf("failed to remove stale container %s: %w", cfg.Name, err)
+			}
+		}
+
+		resp, err := dm.createSpecContainer(ctx, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to create container %s: %w", cfg.Name, err)
+		}
+		if err := dm.StartContainer(ctx, resp.ID); err != nil {
+			return fmt.Errorf("failed to start container %s: %w", cfg.Name, err)
+		}
+	}
+
+	if prune {
+		for name, c := range existingByName {
+			if desired[name] {
+				continue
+			}
+			if err := dm.StopContainer(ctx, c.ID); err != nil {
+				return fmt.Errorf("failed to stop pruned container %s: %w", name, err)
+			}
+			if err := dm.RemoveContainer(ctx, c.ID); err != nil {
+				return fmt.Errorf("failed to remove pruned container %s: %w", name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// createSpecContainer creates a container from a manifest entry, wiring up
+// the env, ports, labels, command, entrypoint, and restart policy fields
+// CreateContainer doesn't know about. Mounts are auto-created the same way
+// CreateContainer does.
+func (dm *DockerManager) createSpecContainer(ctx context.Context, cfg ContainerConfig) (container.CreateResponse, error) {
+	networkExists, err := dm.NetworkExists(ctx, dm.networkName)
+	if err != nil {
+		return container.CreateResponse{}, fmt.Errorf("failed to check if network exists: %w", err)
+	}
+	if !networkExists {
+		return container.CreateResponse{}, errdefs.NotFound(fmt.Errorf("network %s does not exist", dm.networkName))
+	}
+
+	hostConfig := &container.HostConfig{RestartPolicy: parseRestartPolicy(cfg.Restart, cfg.MaxRetries)}
+
+	for _, v := range cfg.Volumes {
+		m, err := parseVolumeMount(v)
+		if err != nil {
+			return container.CreateResponse{}, err
+		}
+
+		exists, err := dm.VolumeExists(ctx, m.VolumeName)
+		if err != nil {
+			return container.CreateResponse{}, fmt.Errorf("failed to check if volume %s exists: %w", m.VolumeName, err)
+		}
+		if !exists {
+			if _, err := dm.CreateVolume(ctx, m.VolumeName, "", nil, nil); err != nil {
+				return container.CreateResponse{}, fmt.Errorf("failed to auto-create volume %s: %w", m.VolumeName, err)
+			}
+		}
+
+		hostConfig.Mounts = append(hostConfig.Mounts, mount.Mount{
+			Type:     mount.TypeVolume,
+			Source:   m.VolumeName,
+			Target:   m.Target,
+			ReadOnly: m.ReadOnly,
+		})
+	}
+
+	exposedPorts := nat.PortSet{}
+	portBindings := nat.PortMap{}
+	for _, p := range cfg.Ports {
+		port, binding, err := parsePortBinding(p)
+		if err != nil {
+			return container.CreateResponse{}, err
+		}
+		exposedPorts[port] = struct{}{}
+		portBindings[port] = append(portBindings[port], binding)
+	}
+	hostConfig.PortBindings = portBindings
+
+	labels := dm.managedLabels(cfg.Labels)
+	labels[LabelConfigHash] = configHash(cfg)
+
+	healthConfig, err := cfg.Healthcheck.toDockerHealthConfig()
+	if err != nil {
+		return container.CreateResponse{}, err
+	}
+
+	resp, err := dm.cli.ContainerCreate(ctx, &container.Config{
+		Image:        cfg.Image,
+		Env:          cfg.Env,
+		Cmd:          cfg.Command,
+		Entrypoint:   cfg.Entrypoint,
+		Labels:       labels,
+		ExposedPorts: exposedPorts,
+		Healthcheck:  healthConfig,
+	}, hostConfig,
+		&network.NetworkingConfig{
+			EndpointsConfig: map[string]*network.EndpointSettings{
+				dm.networkName: {},
+			},
+		}, &dm.defaultPlatform, cfg.Name)
+	return resp, wrapClientErr(err)
+}
+
+// parseVolumeMount parses a "volumeName:/target[:ro]" spec.
+func parseVolumeMount(spec string) (Mount, error) {
+	parts := strings.Split(spec, ":")
+	if len(parts) < 2 || len(parts) > 3 {
+		return Mount{}, fmt.Errorf("invalid volume spec %q, expected volumeName:/target[:ro]", spec)
+	}
+
+	m := Mount{VolumeName: parts[0], Target: parts[1]}
+	if len(parts) == 3 {
+		if parts[2] != "ro" {
+			return Mount{}, fmt.Errorf("invalid volume spec %q, expected trailing :ro", spec)
+		}
+		m.ReadOnly = true
+	}
+
+	return m, nil
+}
+
+// parsePortBinding parses a "hostPort:containerPort[/proto]" spec.
+func parsePortBinding(spec string) (nat.Port, nat.PortBinding, error) {
+	hostPart, containerPart, ok := strings.Cut(spec, ":")
+	if !ok {
+		return "", nat.PortBinding{}, fmt.Errorf("invalid port spec %q, expected hostPort:containerPort[/proto]", spec)
+	}
+
+	proto := "tcp"
+	if cp, p, ok := strings.Cut(containerPart, "/"); ok {
+		containerPart, proto = cp, p
+	}
+
+	if _, err := strconv.Atoi(hostPart); err != nil {
+		return "", nat.PortBinding{}, fmt.Errorf("invalid host port in %q: %w", spec, err)
+	}
+
+	port, err := nat.NewPort(proto, containerPart)
+	if err != nil {
+		return "", nat.PortBinding{}, fmt.Errorf("invalid port spec %q: %w", spec, err)
+	}
+
+	return port, nat.PortBinding{HostPort: hostPart}, nil
+}
+
+// parseRestartPolicy maps the manifest's restart field to a Docker restart
+// policy, defaulting to "no restart" when unset. maxRetries is only
+// meaningful for "on-failure".
+func parseRestartPolicy(restart string, maxRetries int) container.RestartPolicy {
+	return container.RestartPolicy{
+		Name:              container.RestartPolicyMode(restart),
+		MaximumRetryCount: maxRetries,
+	}
+}
+
+// orderContainers returns containers in an order that satisfies depends_on,
+// erroring out if the dependency graph has a cycle or references a container
+// not present in the manifest.
+func orderContainers(containers []ContainerConfig) ([]ContainerConfig, error) {
+	byName := make(map[string]ContainerConfig, len(containers))
+	for _, c := range containers {
+		byName[c.Name] = c
+	}
+
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int, len(containers))
+	ordered := make([]ContainerConfig, 0, len(containers))
+
+	var visit func(name string, path []string) error
+	visit = func(name string, path []string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			return fmt.Errorf("dependency cycle detected: %s -> %s", strings.Join(path, " -> "), name)
+		}
+
+		c, ok := byName[name]
+		if !ok {
+			return fmt.Errorf("container %q depends on unknown container %q", path[len(path)-1], name)
+		}
+
+		state[name] = visiting
+		for _, dep := range c.DependsOn {
+			if err := visit(dep, append(path, name)); err != nil {
+				return err
+			}
+		}
+		state[name] = visited
+		ordered = append(ordered, c)
+		return nil
+	}
+
+	for _, c := range containers {
+		if err := visit(c.Name, nil); err != nil {
+			return nil, err
+		}
+	}
+
+	return ordered, nil
+}
@@ -0,0 +1,114 @@
+package docker
+
+import (
+	"testing"
+
+	"github.com/docker/go-connections/nat"
+)
+
+func TestOrderContainers(t *testing.T) {
+	containers := []ContainerConfig{
+		{Name: "web", DependsOn: []string{"db", "cache"}},
+		{Name: "db"},
+		{Name: "cache", DependsOn: []string{"db"}},
+	}
+
+	ordered, err := orderContainers(containers)
+	if err != nil {
+		t.Fatalf("orderContainers() error = %v", err)
+	}
+
+	index := make(map[string]int, len(ordered))
+	for i, c := range ordered {
+		index[c.Name] = i
+	}
+
+	if index["db"] > index["cache"] {
+		t.Errorf("expected db before cache, got order %v", ordered)
+	}
+	if index["cache"] > index["web"] {
+		t.Errorf("expected cache before web, got order %v", ordered)
+	}
+	if index["db"] > index["web"] {
+		t.Errorf("expected db before web, got order %v", ordered)
+	}
+}
+
+func TestOrderContainersCycle(t *testing.T) {
+	containers := []ContainerConfig{
+		{Name: "a", DependsOn: []string{"b"}},
+		{Name: "b", DependsOn: []string{"a"}},
+	}
+
+	if _, err := orderContainers(containers); err == nil {
+		t.Fatal("expected a cycle error, but got none")
+	}
+}
+
+func TestOrderContainersUnknownDependency(t *testing.T) {
+	containers := []ContainerConfig{
+		{Name: "a", DependsOn: []string{"missing"}},
+	}
+
+	if _, err := orderContainers(containers); err == nil {
+		t.Fatal("expected an unknown-dependency error, but got none")
+	}
+}
+
+func TestParseVolumeMount(t *testing.T) {
+	m, err := parseVolumeMount("data:/var/lib/data")
+	if err != nil {
+		t.Fatalf("parseVolumeMount() error = %v", err)
+	}
+	if m.VolumeName != "data" || m.Target != "/var/lib/data" || m.ReadOnly {
+		t.Errorf("parseVolumeMount() = %+v, unexpected", m)
+	}
+}
+
+func TestParseVolumeMountReadOnly(t *testing.T) {
+	m, err := parseVolumeMount("data:/var/lib/data:ro")
+	if err != nil {
+		t.Fatalf("parseVolumeMount() error = %v", err)
+	}
+	if !m.ReadOnly {
+		t.Errorf("expected ReadOnly to be true, got %+v", m)
+	}
+}
+
+func TestParseVolumeMountInvalid(t *testing.T) {
+	if _, err := parseVolumeMount("data"); err == nil {
+		t.Fatal("expected an error for a spec with no target, but got none")
+	}
+	if _, err := parseVolumeMount("data:/target:rw"); err == nil {
+		t.Fatal("expected an error for an invalid trailing flag, but got none")
+	}
+}
+
+func TestParsePortBinding(t *testing.T) {
+	port, binding, err := parsePortBinding("8080:80")
+	if err != nil {
+		t.Fatalf("parsePortBinding() error = %v", err)
+	}
+	if port != nat.Port("80/tcp") || binding.HostPort != "8080" {
+		t.Errorf("parsePortBinding() = (%v, %+v), unexpected", port, binding)
+	}
+}
+
+func TestParsePortBindingWithProto(t *testing.T) {
+	port, binding, err := parsePortBinding("53:53/udp")
+	if err != nil {
+		t.Fatalf("parsePortBinding() error = %v", err)
+	}
+	if port != nat.Port("53/udp") || binding.HostPort != "53" {
+		t.Errorf("parsePortBinding() = (%v, %+v), unexpected", port, binding)
+	}
+}
+
+func TestParsePortBindingInvalid(t *testing.T) {
+	if _, _, err := parsePortBinding("80"); err == nil {
+		t.Fatal("expected an error for a spec with no host port, but got none")
+	}
+	if _, _, err := parsePortBinding("notanumber:80"); err == nil {
+		t.Fatal("expected an error for a non-numeric host port, but got none")
+	}
+}
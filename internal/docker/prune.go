@@ -0,0 +1,31 @@
+package docker
+
+import "context"
+
+// PruneManagedContainers stops and removes every container hiveden created
+// (identified by the LabelManaged label), regardless of whether it's tracked
+// by a manifest, and returns the IDs removed. Unlike Apply's Prune option,
+// which only removes containers missing from a specific manifest, this is
+// the blunt "clean up everything hiveden owns" operation used after restarts
+// or when abandoning a project entirely.
+func (dm *DockerManager) PruneManagedContainers(ctx context.Context) ([]string, error) {
+	containers, err := dm.ListContainersFiltered(ctx, true, ContainerFilter{
+		Labels: map[string]string{LabelManaged: "true"},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var removed []string
+	for _, c := range containers {
+		if err := dm.StopContainer(ctx, c.ID); err != nil {
+			return removed, err
+		}
+		if err := dm.RemoveContainer(ctx, c.ID); err != nil {
+			return removed, err
+		}
+		removed = append(removed, c.ID)
+	}
+
+	return removed, nil
+}
@@ -0,0 +1,134 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/docker/docker/api/types/image"
+	"github.com/docker/docker/pkg/jsonmessage"
+	"gopkg.in/yaml.v2"
+)
+
+// PullImage pulls ref, streaming decoded progress events on the returned
+// channel. The channel is closed once the pull completes or fails.
+func (dm *DockerManager) PullImage(ctx context.Context, ref string, authConfig string) (<-chan PullProgress, error) {
+	rc, err := dm.cli.ImagePull(ctx, ref, image.PullOptions{RegistryAuth: authConfig})
+	if err != nil {
+		return nil, fmt.Errorf("failed to pull image %s: %w", ref, err)
+	}
+
+	progress := make(chan PullProgress)
+	go func() {
+		defer close(progress)
+		defer rc.Close()
+
+		decoder := json.NewDecoder(rc)
+		for {
+			var msg jsonmessage.JSONMessage
+			if err := decoder.Decode(&msg); err != nil {
+				if err != io.EOF {
+					progress <- PullProgress{Status: fmt.Sprintf("error: %v", err)}
+				}
+				return
+			}
+
+			p := PullProgress{Layer: msg.ID, Status: msg.Status}
+			if msg.Progress != nil {
+				p.Current = msg.Progress.Current
+				p.Total = msg.Progress.Total
+			}
+			progress <- p
+		}
+	}()
+
+	return progress, nil
+}
+
+// ImportManagedContainers is the mirror of ExportManagedContainers: it reads
+// a manifest previously produced by export, diffs it against the currently
+// running hiveden-managed containers, and reconciles the host to match —
+// pulling each configured image, creating and starting missing containers,
+// and recreating any container whose running image ID no longer matches
+// what was just pulled (catching a moved tag like "myimage:latest", not just
+// an outright image-reference change).
+func (dm *DockerManager) ImportManagedContainers(ctx context.Context, filePath string, progress chan<- PullProgress) error {
+	defer close(progress)
+
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	var config struct {
+		Containers []ContainerConfig `yaml:"containers"`
+	}
+	if err := yaml.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+
+	running, err := dm.ListContainers(ctx, true)
+	if err != nil {
+		return fmt.Errorf("failed to list running containers: %w", err)
+	}
+
+	byName := make(map[string]ContainerInfo, len(running))
+	for _, c := range running {
+		if c.ManagedBy == "hiveden" {
+			byName[c.Name] = c
+		}
+	}
+
+	for _, desired := range config.Containers {
+		existing, ok := byName[desired.Name]
+
+		pullEvents, err := dm.PullImage(ctx, desired.Image, "")
+		if err != nil {
+			return fmt.Errorf("failed to pull image for %s: %w", desired.Name, err)
+		}
+		for p := range pullEvents {
+			progress <- p
+		}
+
+		imageID, err := dm.imageID(ctx, desired.Image)
+		if err != nil {
+			return fmt.Errorf("failed to resolve image %s: %w", desired.Image, err)
+		}
+		if ok && existing.ImageID == imageID {
+			continue
+		}
+
+		if ok {
+			if err := dm.StopContainer(ctx, existing.ID); err != nil {
+				return fmt.Errorf("failed to stop stale container %s: %w", desired.Name, err)
+			}
+			if err := dm.RemoveContainer(ctx, existing.ID); err != nil {
+				return fmt.Errorf("failed to remove stale container %s: %w", desired.Name, err)
+			}
+		}
+
+		resp, err := dm.CreateContainer(ctx, desired.Image, desired.Name, "", nil)
+		if err != nil {
+			return fmt.Errorf("failed to create container %s: %w", desired.Name, err)
+		}
+		if err := dm.StartContainer(ctx, resp.ID); err != nil {
+			return fmt.Errorf("failed to start container %s: %w", desired.Name, err)
+		}
+	}
+
+	return nil
+}
+
+// imageID resolves ref to the locally pulled image's content ID, so staleness
+// can be judged against what was actually pulled rather than the configured
+// reference string — a tag like "myimage:latest" is equal before and after
+// its upstream digest moves, but the resolved image ID isn't.
+func (dm *DockerManager) imageID(ctx context.Context, ref string) (string, error) {
+	info, err := dm.cli.ImageInspect(ctx, ref)
+	if err != nil {
+		return "", wrapClientErr(err)
+	}
+	return info.ID, nil
+}
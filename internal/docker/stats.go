@@ -0,0 +1,88 @@
+package docker
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// ContainerStats is a normalized snapshot of a container's resource usage,
+// derived from the raw container.StatsResponse the daemon returns.
+type ContainerStats struct {
+	CPUPercent      float64
+	MemoryUsage     uint64
+	MemoryLimit     uint64
+	NetworkRxBytes  uint64
+	NetworkTxBytes  uint64
+	BlockReadBytes  uint64
+	BlockWriteBytes uint64
+}
+
+// Stats streams normalized resource usage snapshots for a container. With
+// stream=false a single snapshot is sent and the channel is closed; with
+// stream=true it keeps sending until ctx is canceled or the daemon closes
+// the underlying stream.
+func (dm *DockerManager) Stats(ctx context.Context, containerID string, stream bool) (<-chan ContainerStats, error) {
+	resp, err := dm.cli.ContainerStats(ctx, containerID, stream)
+	if err != nil {
+		return nil, wrapClientErr(err)
+	}
+
+	ch := make(chan ContainerStats)
+	go func() {
+		defer close(ch)
+		defer resp.Body.Close()
+
+		decoder := json.NewDecoder(resp.Body)
+		for {
+			var raw container.StatsResponse
+			if err := decoder.Decode(&raw); err != nil {
+				return
+			}
+
+			select {
+			case ch <- normalizeStats(raw):
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	return ch, nil
+}
+
+// normalizeStats computes CPU%, memory, network, and block I/O totals from a
+// raw stats snapshot the same way `docker stats` does.
+func normalizeStats(raw container.StatsResponse) ContainerStats {
+	stats := ContainerStats{
+		MemoryUsage: raw.MemoryStats.Usage,
+		MemoryLimit: raw.MemoryStats.Limit,
+	}
+
+	cpuDelta := float64(raw.CPUStats.CPUUsage.TotalUsage) - float64(raw.PreCPUStats.CPUUsage.TotalUsage)
+	systemDelta := float64(raw.CPUStats.SystemUsage) - float64(raw.PreCPUStats.SystemUsage)
+	if cpuDelta > 0 && systemDelta > 0 {
+		onlineCPUs := float64(raw.CPUStats.OnlineCPUs)
+		if onlineCPUs == 0 {
+			onlineCPUs = float64(len(raw.CPUStats.CPUUsage.PercpuUsage))
+		}
+		stats.CPUPercent = (cpuDelta / systemDelta) * onlineCPUs * 100
+	}
+
+	for _, net := range raw.Networks {
+		stats.NetworkRxBytes += net.RxBytes
+		stats.NetworkTxBytes += net.TxBytes
+	}
+
+	for _, entry := range raw.BlkioStats.IoServiceBytesRecursive {
+		switch entry.Op {
+		case "Read", "read":
+			stats.BlockReadBytes += entry.Value
+		case "Write", "write":
+			stats.BlockWriteBytes += entry.Value
+		}
+	}
+
+	return stats
+}
@@ -0,0 +1,123 @@
+package docker
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/docker/docker/api/types/container"
+)
+
+// Supervise watches hiveden-managed containers for "die" and
+// "health_status: unhealthy" events and restarts them according to each
+// container's configured restart policy, backing off exponentially (capped
+// at one minute) between attempts and giving up once the policy's
+// MaximumRetryCount is reached. A container's backoff and attempt count are
+// reset when it reports "health_status: healthy". It blocks until ctx is
+// canceled or the underlying event watch ends.
+func (dm *DockerManager) Supervise(ctx context.Context) error {
+	const (
+		initialBackoff = time.Second
+		maxBackoff     = time.Minute
+	)
+
+	events, err := dm.Watch(ctx)
+	if err != nil {
+		return err
+	}
+
+	var mu sync.Mutex
+	backoff := map[string]time.Duration{}
+	attempts := map[string]int{}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case e, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if e.Kind != "container" {
+				continue
+			}
+
+			switch {
+			case strings.HasPrefix(e.Action, "health_status: healthy"):
+				mu.Lock()
+				delete(backoff, e.ID)
+				delete(attempts, e.ID)
+				mu.Unlock()
+
+			case e.Action == "die" || strings.HasPrefix(e.Action, "health_status: unhealthy"):
+				policy, err := dm.restartPolicyFor(ctx, e.ID)
+				if err != nil || !shouldRestart(policy) {
+					continue
+				}
+
+				mu.Lock()
+				if policy.MaximumRetryCount > 0 && attempts[e.ID] >= policy.MaximumRetryCount {
+					mu.Unlock()
+					continue
+				}
+				attempts[e.ID]++
+
+				delay := backoff[e.ID]
+				if delay == 0 {
+					delay = initialBackoff
+				}
+				backoff[e.ID] = minDuration(delay*2, maxBackoff)
+				mu.Unlock()
+
+				go dm.restartAfter(ctx, e.ID, delay)
+			}
+		}
+	}
+}
+
+// restartPolicyFor inspects containerID and returns the restart policy it
+// was created with, so Supervise can decide whether and how many times to
+// restart it instead of doing so unconditionally.
+func (dm *DockerManager) restartPolicyFor(ctx context.Context, containerID string) (container.RestartPolicy, error) {
+	info, err := dm.cli.ContainerInspect(ctx, containerID)
+	if err != nil {
+		return container.RestartPolicy{}, wrapClientErr(err)
+	}
+	if info.HostConfig == nil {
+		return container.RestartPolicy{}, nil
+	}
+	return info.HostConfig.RestartPolicy, nil
+}
+
+// shouldRestart reports whether policy calls for Supervise to restart a
+// container at all. A policy of "" or "no" means the container was
+// deliberately left un-managed on failure, so Supervise leaves it alone.
+func shouldRestart(policy container.RestartPolicy) bool {
+	switch policy.Name {
+	case "", container.RestartPolicyDisabled:
+		return false
+	default:
+		return true
+	}
+}
+
+// restartAfter waits delay (or until ctx is canceled) and then starts
+// containerID, swallowing the error — a failed restart attempt will surface
+// again as the next "die" event and be retried with a longer backoff, up to
+// the policy's MaximumRetryCount.
+func (dm *DockerManager) restartAfter(ctx context.Context, containerID string, delay time.Duration) {
+	select {
+	case <-ctx.Done():
+		return
+	case <-time.After(delay):
+	}
+	dm.StartContainer(ctx, containerID)
+}
+
+func minDuration(a, b time.Duration) time.Duration {
+	if a < b {
+		return a
+	}
+	return b
+}
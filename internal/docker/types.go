@@ -2,26 +2,88 @@ package docker
 
 import (
 	"context"
+	"io"
+	"time"
 
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/api/types/events"
+	"github.com/docker/docker/api/types/image"
 	"github.com/docker/docker/api/types/network"
+	"github.com/docker/docker/api/types/volume"
 	v1 "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 // ContainerInfo holds custom information about a container.
 type ContainerInfo struct {
-	ID        string
-	Image     string
-	ImageID   string
-	Name      string
-	Uptime    string
-	ManagedBy string
+	ID         string
+	Image      string
+	ImageID    string
+	Name       string
+	Uptime     string
+	ManagedBy  string
+	Project    string
+	ConfigHash string
+}
+
+// ContainerFilter narrows ListContainersFiltered to containers matching all
+// of its set fields, mirroring the subset of Docker's filter API hiveden
+// needs.
+type ContainerFilter struct {
+	Labels   map[string]string
+	Name     string // regex matched against the container name
+	Status   string // e.g. "running", "exited"
+	Ancestor string // image name or ID
+}
+
+// NetworkFilter narrows ListNetworksFiltered to networks matching all of its
+// set fields.
+type NetworkFilter struct {
+	Labels map[string]string
+	Name   string
 }
 
 // NetworkInfo holds custom information about a network.
 type NetworkInfo struct {
-	ID   string
-	Name string
+	ID     string
+	Name   string
+	Labels map[string]string
+}
+
+// VolumeInfo holds custom information about a volume, mirroring NetworkInfo.
+type VolumeInfo struct {
+	ID         string
+	Name       string
+	Driver     string
+	Mountpoint string
+	Labels     map[string]string
+}
+
+// Mount describes a managed volume to attach to a container at creation time.
+type Mount struct {
+	VolumeName string
+	Target     string
+	ReadOnly   bool
+}
+
+// Event is a typed, decoded Docker lifecycle event for a hiveden-managed
+// resource (container, network, or volume).
+type Event struct {
+	Kind       string
+	Action     string
+	ID         string
+	Name       string
+	Time       time.Time
+	Attributes map[string]string
+}
+
+// PullProgress is a decoded entry from the JSON message stream returned by
+// an image pull, normalized into the fields callers actually care about.
+type PullProgress struct {
+	Layer   string
+	Status  string
+	Current int64
+	Total   int64
 }
 
 // Client is an interface for the Docker client.
@@ -34,15 +96,55 @@ type Client interface {
 	NetworkCreate(ctx context.Context, name string, options network.CreateOptions) (network.CreateResponse, error)
 	NetworkRemove(ctx context.Context, networkID string) error
 	NetworkList(ctx context.Context, options network.ListOptions) ([]network.Summary, error)
+	VolumeCreate(ctx context.Context, options volume.CreateOptions) (volume.Volume, error)
+	VolumeRemove(ctx context.Context, volumeID string, force bool) error
+	VolumeList(ctx context.Context, options volume.ListOptions) (volume.ListResponse, error)
+	Events(ctx context.Context, options events.ListOptions) (<-chan events.Message, <-chan error)
+	ImagePull(ctx context.Context, ref string, options image.PullOptions) (io.ReadCloser, error)
+	Info(ctx context.Context) (types.Info, error)
+	ContainerLogs(ctx context.Context, containerID string, options container.LogsOptions) (io.ReadCloser, error)
+	ContainerStats(ctx context.Context, containerID string, stream bool) (container.StatsResponseReader, error)
+	ContainerExecCreate(ctx context.Context, containerID string, options container.ExecOptions) (container.ExecCreateResponse, error)
+	ContainerExecAttach(ctx context.Context, execID string, options container.ExecStartOptions) (types.HijackedResponse, error)
+	ContainerExecResize(ctx context.Context, execID string, options container.ResizeOptions) error
+	ContainerInspect(ctx context.Context, containerID string) (container.InspectResponse, error)
+	ContainerExecInspect(ctx context.Context, execID string) (container.ExecInspect, error)
+	ImageInspect(ctx context.Context, imageID string) (image.InspectResponse, error)
 }
 
 // ContainerConfig represents a container in the YAML config file.
 type ContainerConfig struct {
-	Name  string `yaml:"name"`
-	Image string `yaml:"image"`
+	Name        string             `yaml:"name"`
+	Image       string             `yaml:"image"`
+	Env         []string           `yaml:"env,omitempty"`
+	Ports       []string           `yaml:"ports,omitempty"` // "hostPort:containerPort[/proto]"
+	Labels      map[string]string  `yaml:"labels,omitempty"`
+	Volumes     []string           `yaml:"volumes,omitempty"` // "volumeName:/target[:ro]"
+	Command     []string           `yaml:"command,omitempty"`
+	Entrypoint  []string           `yaml:"entrypoint,omitempty"`
+	Restart     string             `yaml:"restart,omitempty"`     // "", "always", "on-failure", "unless-stopped"
+	MaxRetries  int                `yaml:"max_retries,omitempty"` // only meaningful with restart: on-failure
+	DependsOn   []string           `yaml:"depends_on,omitempty"`
+	Healthcheck *HealthcheckConfig `yaml:"healthcheck,omitempty"`
 }
 
 // NetworkConfig represents a network in the YAML config file.
 type NetworkConfig struct {
 	Name string `yaml:"name"`
 }
+
+// VolumeConfig represents a volume in the YAML config file.
+type VolumeConfig struct {
+	Name       string            `yaml:"name"`
+	Driver     string            `yaml:"driver,omitempty"`
+	DriverOpts map[string]string `yaml:"driver_opts,omitempty"`
+	Labels     map[string]string `yaml:"labels,omitempty"`
+}
+
+// Manifest is the top-level shape of a play manifest: the desired networks,
+// volumes, and containers hiveden should reconcile the host to.
+type Manifest struct {
+	Networks   []NetworkConfig   `yaml:"networks"`
+	Volumes    []VolumeConfig    `yaml:"volumes"`
+	Containers []ContainerConfig `yaml:"containers"`
+}
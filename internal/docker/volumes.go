@@ -0,0 +1,76 @@
+package docker
+
+import (
+	"context"
+
+	"github.com/docker/docker/api/types/filters"
+	"github.com/docker/docker/api/types/volume"
+)
+
+// CreateVolume creates a new named volume, stamping it with hiveden's
+// managed labels merged with any extras the caller supplied (mirroring
+// CreateContainer, so every volume created through this method — not just
+// ones created via `hiveden play` — is reliably found by prune/filter/export).
+func (dm *DockerManager) CreateVolume(ctx context.Context, name string, driver string, driverOpts map[string]string, labels map[string]string) (VolumeInfo, error) {
+	v, err := dm.cli.VolumeCreate(ctx, volume.CreateOptions{
+		Name:       name,
+		Driver:     driver,
+		DriverOpts: driverOpts,
+		Labels:     dm.managedLabels(labels),
+	})
+	if err != nil {
+		return VolumeInfo{}, wrapClientErr(err)
+	}
+
+	return VolumeInfo{
+		ID:         v.Name,
+		Name:       v.Name,
+		Driver:     v.Driver,
+		Mountpoint: v.Mountpoint,
+		Labels:     v.Labels,
+	}, nil
+}
+
+// RemoveVolume removes a volume by name.
+func (dm *DockerManager) RemoveVolume(ctx context.Context, name string, force bool) error {
+	return wrapClientErr(dm.cli.VolumeRemove(ctx, name, force))
+}
+
+// ListVolumes lists all volumes.
+func (dm *DockerManager) ListVolumes(ctx context.Context) ([]VolumeInfo, error) {
+	resp, err := dm.cli.VolumeList(ctx, volume.ListOptions{})
+	if err != nil {
+		return nil, wrapClientErr(err)
+	}
+
+	var volumeInfos []VolumeInfo
+	for _, v := range resp.Volumes {
+		volumeInfos = append(volumeInfos, VolumeInfo{
+			ID:         v.Name,
+			Name:       v.Name,
+			Driver:     v.Driver,
+			Mountpoint: v.Mountpoint,
+			Labels:     v.Labels,
+		})
+	}
+
+	return volumeInfos, nil
+}
+
+// VolumeExists checks if a volume exists.
+func (dm *DockerManager) VolumeExists(ctx context.Context, name string) (bool, error) {
+	resp, err := dm.cli.VolumeList(ctx, volume.ListOptions{
+		Filters: filters.NewArgs(filters.Arg("name", name)),
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, v := range resp.Volumes {
+		if v.Name == name {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
@@ -0,0 +1,118 @@
+// Package errdefs defines a small taxonomy of error categories that callers
+// across hiveden (the docker and arch packages, primarily) can wrap errors
+// in, and that the HTTP API can map to status codes without inspecting
+// error strings. It follows the moby/moby errdefs pattern: each category is
+// a marker interface (ErrNotFound, ErrConflict, ...), with a constructor of
+// the matching short name (NotFound, Conflict, ...) that wraps an error so
+// it satisfies that interface.
+package errdefs
+
+// ErrNotFound marks an error as "the requested resource does not exist".
+type ErrNotFound interface {
+	NotFound() bool
+}
+
+// ErrConflict marks an error as "the request conflicts with the current
+// state of the resource" (e.g. a name already in use, a pacman transaction
+// conflict).
+type ErrConflict interface {
+	Conflict() bool
+}
+
+// ErrInvalidParameter marks an error as caused by a malformed request.
+type ErrInvalidParameter interface {
+	InvalidParameter() bool
+}
+
+// ErrUnauthorized marks an error as "the caller is not authenticated".
+type ErrUnauthorized interface {
+	Unauthorized() bool
+}
+
+// ErrForbidden marks an error as "the caller is authenticated but not
+// allowed to perform this action".
+type ErrForbidden interface {
+	Forbidden() bool
+}
+
+// ErrUnavailable marks an error as "the dependency the request needs is
+// temporarily unavailable" (e.g. the Docker daemon is unreachable).
+type ErrUnavailable interface {
+	Unavailable() bool
+}
+
+type wrapped struct{ error }
+
+func (w wrapped) Unwrap() error { return w.error }
+
+type notFoundErr struct{ wrapped }
+
+func (notFoundErr) NotFound() bool { return true }
+
+// NotFound wraps err so that it satisfies ErrNotFound. A nil err returns nil.
+func NotFound(err error) error {
+	if err == nil {
+		return nil
+	}
+	return notFoundErr{wrapped{err}}
+}
+
+type conflictErr struct{ wrapped }
+
+func (conflictErr) Conflict() bool { return true }
+
+// Conflict wraps err so that it satisfies ErrConflict. A nil err returns nil.
+func Conflict(err error) error {
+	if err == nil {
+		return nil
+	}
+	return conflictErr{wrapped{err}}
+}
+
+type invalidParameterErr struct{ wrapped }
+
+func (invalidParameterErr) InvalidParameter() bool { return true }
+
+// InvalidParameter wraps err so that it satisfies ErrInvalidParameter. A nil err returns nil.
+func InvalidParameter(err error) error {
+	if err == nil {
+		return nil
+	}
+	return invalidParameterErr{wrapped{err}}
+}
+
+type unauthorizedErr struct{ wrapped }
+
+func (unauthorizedErr) Unauthorized() bool { return true }
+
+// Unauthorized wraps err so that it satisfies ErrUnauthorized. A nil err returns nil.
+func Unauthorized(err error) error {
+	if err == nil {
+		return nil
+	}
+	return unauthorizedErr{wrapped{err}}
+}
+
+type forbiddenErr struct{ wrapped }
+
+func (forbiddenErr) Forbidden() bool { return true }
+
+// Forbidden wraps err so that it satisfies ErrForbidden. A nil err returns nil.
+func Forbidden(err error) error {
+	if err == nil {
+		return nil
+	}
+	return forbiddenErr{wrapped{err}}
+}
+
+type unavailableErr struct{ wrapped }
+
+func (unavailableErr) Unavailable() bool { return true }
+
+// Unavailable wraps err so that it satisfies ErrUnavailable. A nil err returns nil.
+func Unavailable(err error) error {
+	if err == nil {
+		return nil
+	}
+	return unavailableErr{wrapped{err}}
+}
@@ -0,0 +1,39 @@
+package errdefs
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestStatusCode(t *testing.T) {
+	base := errors.New("boom")
+
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{"not found", NotFound(base), http.StatusNotFound},
+		{"conflict", Conflict(base), http.StatusConflict},
+		{"invalid parameter", InvalidParameter(base), http.StatusBadRequest},
+		{"unauthorized", Unauthorized(base), http.StatusUnauthorized},
+		{"forbidden", Forbidden(base), http.StatusForbidden},
+		{"unavailable", Unavailable(base), http.StatusServiceUnavailable},
+		{"unwrapped", base, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := StatusCode(tt.err); got != tt.want {
+				t.Errorf("StatusCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotFoundNilError(t *testing.T) {
+	if err := NotFound(nil); err != nil {
+		t.Errorf("NotFound(nil) = %v, want nil", err)
+	}
+}
@@ -0,0 +1,102 @@
+package errdefs
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// errorResponse is the stable JSON body every mapped error is rendered as.
+type errorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// StatusCode classifies err against the marker interfaces in this package
+// and returns the HTTP status code it should map to, defaulting to 500.
+func StatusCode(err error) int {
+	switch {
+	case asErrNotFound(err):
+		return http.StatusNotFound
+	case asErrConflict(err):
+		return http.StatusConflict
+	case asErrInvalidParameter(err):
+		return http.StatusBadRequest
+	case asErrUnauthorized(err):
+		return http.StatusUnauthorized
+	case asErrForbidden(err):
+		return http.StatusForbidden
+	case asErrUnavailable(err):
+		return http.StatusServiceUnavailable
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// code returns the machine-readable code embedded in the JSON error body.
+func code(status int) string {
+	switch status {
+	case http.StatusNotFound:
+		return "not_found"
+	case http.StatusConflict:
+		return "conflict"
+	case http.StatusBadRequest:
+		return "invalid_parameter"
+	case http.StatusUnauthorized:
+		return "unauthorized"
+	case http.StatusForbidden:
+		return "forbidden"
+	case http.StatusServiceUnavailable:
+		return "unavailable"
+	default:
+		return "internal"
+	}
+}
+
+func asErrNotFound(err error) bool {
+	var e ErrNotFound
+	return errors.As(err, &e) && e.NotFound()
+}
+
+func asErrConflict(err error) bool {
+	var e ErrConflict
+	return errors.As(err, &e) && e.Conflict()
+}
+
+func asErrInvalidParameter(err error) bool {
+	var e ErrInvalidParameter
+	return errors.As(err, &e) && e.InvalidParameter()
+}
+
+func asErrUnauthorized(err error) bool {
+	var e ErrUnauthorized
+	return errors.As(err, &e) && e.Unauthorized()
+}
+
+func asErrForbidden(err error) bool {
+	var e ErrForbidden
+	return errors.As(err, &e) && e.Forbidden()
+}
+
+func asErrUnavailable(err error) bool {
+	var e ErrUnavailable
+	return errors.As(err, &e) && e.Unavailable()
+}
+
+// Middleware inspects the last error attached to the gin context (via
+// c.Error) and, if present, writes the mapped status code and a stable JSON
+// body instead of letting it fall through to gin's default handling.
+func Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Next()
+
+		if len(c.Errors) == 0 {
+			return
+		}
+
+		err := c.Errors.Last().Err
+		status := StatusCode(err)
+		c.JSON(status, errorResponse{Code: code(status), Message: err.Error()})
+	}
+}
@@ -6,6 +6,8 @@ import (
 	"os"
 
 	"github.com/Jguer/go-alpm/v2"
+
+	"github.com/hiveden/hiveden/internal/errdefs"
 )
 
 const (
@@ -60,7 +62,7 @@ func (a *Arch) Install(packages ...string) error {
 		for _, syncDB := range syncDBs.Slice() {
 			pkgs := syncDB.Search([]string{name})
 			if len(pkgs.Slice()) == 0 {
-				return fmt.Errorf("package '%s' not found in remote repositories", name)
+				return errdefs.NotFound(fmt.Errorf("package '%s' not found in remote repositories", name))
 			}
 			remotePkg := pkgs.Slice()[0] // Use the first search result.
 
@@ -101,10 +103,10 @@ func (a *Arch) Install(packages ...string) error {
 	}
 
 	if err := a.handle.TransPrepare(); err != nil {
-		return fmt.Errorf("failed to prepare transaction: %w", err)
+		return errdefs.Conflict(fmt.Errorf("failed to prepare transaction: %w", err))
 	}
 	if err := a.handle.TransCommit(); err != nil {
-		return fmt.Errorf("failed to commit transaction: %w", err)
+		return errdefs.Conflict(fmt.Errorf("failed to commit transaction: %w", err))
 	}
 
 	fmt.Println("Installation/upgrade complete.")
@@ -176,7 +178,7 @@ func (a *Arch) findInstalledPackages(names ...string) ([]alpm.IPackage, error) {
 	for _, name := range names {
 		pkg := localDB.Pkg(name)
 		if pkg == nil {
-			return nil, fmt.Errorf("installed package not found: %s", name)
+			return nil, errdefs.NotFound(fmt.Errorf("installed package not found: %s", name))
 		}
 		pkgs = append(pkgs, pkg)
 	}